@@ -3,27 +3,44 @@ package attestation
 import (
 	"fmt"
 
-	attest "github.com/Sashwat-K/lib-hpcr/common/decrypt"
+	"github.com/Sashwat-K/lib-hpcr/common/envelope"
 	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+	prov "github.com/Sashwat-K/lib-hpcr/common/provider"
 )
 
 const (
 	missingParameterErrStatement = "required parameter is missing"
 )
 
+// Options - optional settings for attestation
+type Options struct {
+	// Provider - crypto implementation to use, openssl is used if nil
+	Provider prov.CryptoProvider
+}
+
 // HpcrGetAttestationRecords - function to get attestation records from encrypted data
 func HpcrGetAttestationRecords(data, privateKey string) (string, error) {
+	return HpcrGetAttestationRecordsWithOptions(data, privateKey, Options{})
+}
+
+// HpcrGetAttestationRecordsWithOptions - function to get attestation records from encrypted data with given options
+func HpcrGetAttestationRecordsWithOptions(data, privateKey string, opts Options) (string, error) {
 	if gen.CheckIfEmpty(data, privateKey) {
 		return "", fmt.Errorf(missingParameterErrStatement)
 	}
-	encodedEncryptedPassword, encodedEncryptedData := gen.GetEncryptPassWorkload(data)
+	encryptedRecords, err := envelope.Parse(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse encrypted attestation records - %v", err)
+	}
+
+	cryptoProvider := prov.GetProvider(opts.Provider)
 
-	password, err := attest.DecryptPassword(encodedEncryptedPassword, privateKey)
+	password, err := cryptoProvider.DecryptPassword(encryptedRecords.Password, privateKey)
 	if err != nil {
 		return "", fmt.Errorf("failed to decrypt password - %v", err)
 	}
 
-	attestationRecords, err := attest.DecryptWorkload(password, encodedEncryptedData)
+	attestationRecords, err := cryptoProvider.DecryptWorkload(password, encryptedRecords.Data)
 	if err != nil {
 		return "", fmt.Errorf("failed to decrypt attestation records - %v", err)
 	}
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+	prov "github.com/Sashwat-K/lib-hpcr/common/provider"
 )
 
 const (
@@ -33,3 +34,23 @@ func TestHpcrGetAttestationRecords(t *testing.T) {
 
 	assert.Contains(t, result, sampleAttestationRecordKey)
 }
+
+// Testcase to check if HpcrGetAttestationRecordsWithOptions() retrieves attestation records using given provider
+func TestHpcrGetAttestationRecordsWithOptions(t *testing.T) {
+	encChecksum, err := gen.ReadDataFromFile(encryptedChecksumPath)
+	if err != nil {
+		t.Errorf("failed to get encrypted checksum - %v", err)
+	}
+
+	privateKeyData, err := gen.ReadDataFromFile(privateKeyPath)
+	if err != nil {
+		t.Errorf("failed to get private key - %v", err)
+	}
+
+	result, err := HpcrGetAttestationRecordsWithOptions(encChecksum, privateKeyData, Options{Provider: prov.NativeProvider{}})
+	if err != nil {
+		t.Errorf("failed to decrypt attestation records - %v", err)
+	}
+
+	assert.Contains(t, result, sampleAttestationRecordKey)
+}
@@ -0,0 +1,160 @@
+package attestation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+)
+
+// rules of policy violations
+const (
+	PolicyRuleVersion    = "version"
+	PolicyRuleRequired   = "required"
+	PolicyRuleAllowed    = "allowed"
+	PolicyRuleUnexpected = "unexpected"
+)
+
+type (
+	// Policy - expected values of attestation records, in YAML or JSON
+	Policy struct {
+		// Versions - allowed HPCR image versions, any version if empty
+		Versions []string `yaml:"versions,omitempty" json:"versions,omitempty"`
+		// Required - entries that must be present
+		Required []string `yaml:"required,omitempty" json:"required,omitempty"`
+		// Entries - allowed sha256 values of entries, entries listed here are expected but optional
+		Entries map[string]EntryPolicy `yaml:"entries,omitempty" json:"entries,omitempty"`
+		// AllowUnexpected - allow entries that are neither required nor listed in entries
+		AllowUnexpected bool `yaml:"allowUnexpected,omitempty" json:"allowUnexpected,omitempty"`
+	}
+
+	// EntryPolicy - expected values of one entry
+	EntryPolicy struct {
+		// Allowed - approved sha256 values (eg: baseimage of approved HPCR versions), any value if empty
+		Allowed []string `yaml:"allowed,omitempty" json:"allowed,omitempty"`
+	}
+
+	// PolicyViolation - reason of failed policy
+	PolicyViolation struct {
+		Rule    string `json:"rule"`
+		Entry   string `json:"entry,omitempty"`
+		Message string `json:"message"`
+	}
+
+	// PolicyReport - result of evaluating policy
+	PolicyReport struct {
+		Passed     bool              `json:"passed"`
+		Violations []PolicyViolation `json:"violations"`
+	}
+)
+
+// ParsePolicy - function to parse attestation policy from YAML or JSON and validate its values
+func ParsePolicy(policy string) (*Policy, error) {
+	if gen.CheckIfEmpty(policy) {
+		return nil, fmt.Errorf(missingParameterErrStatement)
+	}
+
+	var result Policy
+
+	decoder := yaml.NewDecoder(bytes.NewReader([]byte(policy)))
+	decoder.KnownFields(true)
+
+	err := decoder.Decode(&result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal policy - %v", err)
+	}
+
+	for _, name := range result.Required {
+		if name == "" {
+			return nil, fmt.Errorf("required entry name is empty")
+		}
+	}
+
+	for name, entry := range result.Entries {
+		for _, sha256 := range entry.Allowed {
+			if !reRecordSha256.MatchString(sha256) {
+				return nil, fmt.Errorf("allowed value %q of %s is not a sha256", sha256, name)
+			}
+		}
+	}
+
+	return &result, nil
+}
+
+// Evaluate - function to evaluate policy against attestation records
+func (p *Policy) Evaluate(records *AttestationRecords) (PolicyReport, error) {
+	if records == nil {
+		return PolicyReport{}, fmt.Errorf(missingParameterErrStatement)
+	}
+
+	report := PolicyReport{Violations: []PolicyViolation{}}
+
+	add := func(rule, entry, message string, args ...interface{}) {
+		report.Violations = append(report.Violations, PolicyViolation{Rule: rule, Entry: entry, Message: fmt.Sprintf(message, args...)})
+	}
+
+	if len(p.Versions) > 0 && !contains(p.Versions, records.Version) {
+		add(PolicyRuleVersion, "", "version %s is not allowed", records.Version)
+	}
+
+	for _, name := range p.Required {
+		if _, ok := records.Get(name); !ok {
+			add(PolicyRuleRequired, name, "required entry %s is missing", name)
+		}
+	}
+
+	for _, entry := range records.Entries {
+		entryPolicy, listed := p.Entries[entry.Name]
+
+		if len(entryPolicy.Allowed) > 0 && !contains(entryPolicy.Allowed, entry.Sha256) {
+			add(PolicyRuleAllowed, entry.Name, "sha256 %s of %s is not allowed", entry.Sha256, entry.Name)
+		}
+
+		if !listed && !p.AllowUnexpected && !contains(p.Required, entry.Name) {
+			add(PolicyRuleUnexpected, entry.Name, "entry %s is not expected", entry.Name)
+		}
+	}
+
+	report.Passed = len(report.Violations) == 0
+
+	return report, nil
+}
+
+// HpcrEvaluateAttestationPolicy - function to decrypt attestation records and evaluate policy (YAML or JSON) against them
+func HpcrEvaluateAttestationPolicy(data, privateKey, policy string, opts Options) (PolicyReport, error) {
+	parsedPolicy, err := ParsePolicy(policy)
+	if err != nil {
+		return PolicyReport{}, err
+	}
+
+	records, err := HpcrGetAttestationRecordsTyped(data, privateKey, opts)
+	if err != nil {
+		return PolicyReport{}, err
+	}
+
+	return parsedPolicy.Evaluate(records)
+}
+
+// ToJson - function to convert policy report to JSON
+func (r PolicyReport) ToJson() (string, error) {
+	reportJson, err := json.Marshal(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal policy report to JSON - %v", err)
+	}
+
+	return string(reportJson), nil
+}
+
+// contains - function to check if value is in list
+func contains(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
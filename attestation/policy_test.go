@@ -0,0 +1,136 @@
+package attestation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+	prov "github.com/Sashwat-K/lib-hpcr/common/provider"
+)
+
+const (
+	samplePolicyPath = "../samples/attestation/policy.yaml"
+
+	sampleStrictPolicyJson = `{
+  "versions": ["24.7.0"],
+  "required": ["baseimage", "envWorkloadSignature"],
+  "entries": {"baseimage": {"allowed": ["0bf377eea1136f03cbd5fbb1f2d12bb9a40a3e9f97a8438196b549fdafd0786e"]}}
+}`
+)
+
+// Testcase to check if ParsePolicy() parses YAML and JSON policies and rejects invalid values
+func TestParsePolicy(t *testing.T) {
+	policy, err := gen.ReadDataFromFile(samplePolicyPath)
+	if err != nil {
+		t.Errorf("failed to read policy - %v", err)
+	}
+
+	result, err := ParsePolicy(policy)
+	if err != nil {
+		t.Errorf("failed to parse policy - %v", err)
+	}
+
+	assert.Equal(t, result.Versions, []string{"24.3.3"})
+	assert.Len(t, result.Entries, 6)
+
+	result, err = ParsePolicy(sampleStrictPolicyJson)
+	if err != nil {
+		t.Errorf("failed to parse policy - %v", err)
+	}
+
+	assert.Equal(t, result.Required, []string{"baseimage", "envWorkloadSignature"})
+
+	_, err = ParsePolicy("entries:\n  baseimage:\n    allowed: [24.3.3]\n")
+	assert.Error(t, err)
+
+	_, err = ParsePolicy("version: 1\n")
+	assert.Error(t, err)
+}
+
+// Testcase to check if Evaluate() reports violations of version, required, allowed and unexpected rules
+func TestPolicyEvaluate(t *testing.T) {
+	records, err := ParseAttestationRecords(sampleAttestationRecords)
+	if err != nil {
+		t.Errorf("failed to parse attestation records - %v", err)
+	}
+
+	policy, err := gen.ReadDataFromFile(samplePolicyPath)
+	if err != nil {
+		t.Errorf("failed to read policy - %v", err)
+	}
+
+	parsedPolicy, err := ParsePolicy(policy)
+	if err != nil {
+		t.Errorf("failed to parse policy - %v", err)
+	}
+
+	result, err := parsedPolicy.Evaluate(records)
+	if err != nil {
+		t.Errorf("failed to evaluate policy - %v", err)
+	}
+
+	assert.True(t, result.Passed)
+	assert.Empty(t, result.Violations)
+
+	parsedPolicy, err = ParsePolicy(sampleStrictPolicyJson)
+	if err != nil {
+		t.Errorf("failed to parse policy - %v", err)
+	}
+
+	result, err = parsedPolicy.Evaluate(records)
+	if err != nil {
+		t.Errorf("failed to evaluate policy - %v", err)
+	}
+
+	assert.False(t, result.Passed)
+	assert.Equal(t, result.Violations[0], PolicyViolation{Rule: PolicyRuleVersion, Message: "version 24.3.3 is not allowed"})
+	assert.Equal(t, result.Violations[1], PolicyViolation{Rule: PolicyRuleRequired, Entry: "envWorkloadSignature", Message: "required entry envWorkloadSignature is missing"})
+	assert.Equal(t, result.Violations[2], PolicyViolation{Rule: PolicyRuleUnexpected, Entry: EntryRootTarGz, Message: "entry root.tar.gz is not expected"})
+	assert.Equal(t, result.Violations[3].Rule, PolicyRuleAllowed)
+	assert.Len(t, result.Violations, 11)
+
+	parsedPolicy.AllowUnexpected = true
+
+	result, err = parsedPolicy.Evaluate(records)
+	if err != nil {
+		t.Errorf("failed to evaluate policy - %v", err)
+	}
+
+	assert.Len(t, result.Violations, 3)
+
+	_, err = parsedPolicy.Evaluate(nil)
+	assert.Error(t, err)
+}
+
+// Testcase to check if HpcrEvaluateAttestationPolicy() decrypts attestation records and evaluates policy
+func TestHpcrEvaluateAttestationPolicy(t *testing.T) {
+	encChecksum, err := gen.ReadDataFromFile(encryptedChecksumPath)
+	if err != nil {
+		t.Errorf("failed to get encrypted checksum - %v", err)
+	}
+
+	privateKeyData, err := gen.ReadDataFromFile(privateKeyPath)
+	if err != nil {
+		t.Errorf("failed to get private key - %v", err)
+	}
+
+	policy, err := gen.ReadDataFromFile(samplePolicyPath)
+	if err != nil {
+		t.Errorf("failed to read policy - %v", err)
+	}
+
+	result, err := HpcrEvaluateAttestationPolicy(encChecksum, privateKeyData, policy, Options{Provider: prov.NativeProvider{}})
+	if err != nil {
+		t.Errorf("failed to evaluate policy - %v", err)
+	}
+
+	assert.True(t, result.Passed)
+
+	reportJson, err := result.ToJson()
+	if err != nil {
+		t.Errorf("failed to convert policy report to JSON - %v", err)
+	}
+
+	assert.Equal(t, reportJson, `{"passed":true,"violations":[]}`)
+}
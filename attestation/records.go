@@ -0,0 +1,167 @@
+package attestation
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+)
+
+// names of attestation record entries
+const (
+	EntryRootTarGz            = "root.tar.gz"
+	EntryBaseImage            = "baseimage"
+	EntryCidata               = "/dev/disk/by-label/cidata"
+	EntryMetaData             = "cidata/meta-data"
+	EntryUserData             = "cidata/user-data"
+	EntryVendorData           = "cidata/vendor-data"
+	EntryAttestationPublicKey = "attestationPublicKey"
+	EntryEnv                  = "env"
+	EntryWorkload             = "workload"
+
+	machineLinePrefix = "Machine Type/Plant/Serial:"
+)
+
+var (
+	// reRecordSha256 tests if digest of entry is a sha256 hex string
+	reRecordSha256 = regexp.MustCompile(`^[a-f0-9]{64}$`)
+)
+
+type (
+	// AttestationRecords - decrypted attestation records (se-checksums.txt)
+	AttestationRecords struct {
+		// Version - version of HPCR image that generated records
+		Version string `json:"version"`
+		// Machine - machine the instance runs on
+		Machine Machine `json:"machine"`
+		// Entries - measured entries in order of records
+		Entries []AttestationEntry `json:"entries"`
+	}
+
+	// Machine - identifiers of IBM Z machine
+	Machine struct {
+		Type   string `json:"type"`
+		Plant  string `json:"plant"`
+		Serial string `json:"serial"`
+	}
+
+	// AttestationEntry - measured entry of attestation records
+	AttestationEntry struct {
+		Name   string `json:"name"`
+		Sha256 string `json:"sha256"`
+	}
+)
+
+// ParseAttestationRecords - function to parse decrypted attestation records into version, machine and ordered entries
+func ParseAttestationRecords(records string) (*AttestationRecords, error) {
+	if gen.CheckIfEmpty(records) {
+		return nil, fmt.Errorf(missingParameterErrStatement)
+	}
+
+	lines := strings.Split(strings.ReplaceAll(records, "\r\n", "\n"), "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) < 2 {
+		return nil, fmt.Errorf("attestation records must have version and machine lines")
+	}
+
+	result := &AttestationRecords{Version: strings.TrimSpace(lines[0]), Entries: []AttestationEntry{}}
+	if result.Version == "" || strings.ContainsAny(result.Version, " \t") {
+		return nil, fmt.Errorf("line 1 - invalid version %q", lines[0])
+	}
+
+	machineLine := strings.TrimSpace(lines[1])
+	if !strings.HasPrefix(machineLine, machineLinePrefix) {
+		return nil, fmt.Errorf("line 2 - expected %q", machineLinePrefix)
+	}
+
+	machine := strings.Split(strings.TrimSpace(strings.TrimPrefix(machineLine, machineLinePrefix)), "/")
+	if len(machine) != 3 {
+		return nil, fmt.Errorf("line 2 - machine must have type, plant and serial")
+	}
+	result.Machine = Machine{Type: machine[0], Plant: machine[1], Serial: machine[2]}
+
+	seen := map[string]bool{}
+
+	for index, line := range lines[2:] {
+		lineNumber := index + 3
+
+		sha256, name, ok := strings.Cut(strings.TrimSpace(line), " ")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("line %d - expected \"<sha256> <name>\"", lineNumber)
+		}
+
+		if !reRecordSha256.MatchString(sha256) {
+			return nil, fmt.Errorf("line %d - invalid sha256 of %s", lineNumber, name)
+		}
+
+		if seen[name] {
+			return nil, fmt.Errorf("line %d - duplicate entry %s", lineNumber, name)
+		}
+		seen[name] = true
+
+		result.Entries = append(result.Entries, AttestationEntry{Name: name, Sha256: sha256})
+	}
+
+	return result, nil
+}
+
+// HpcrGetAttestationRecordsTyped - function to decrypt attestation records and parse them
+func HpcrGetAttestationRecordsTyped(data, privateKey string, opts Options) (*AttestationRecords, error) {
+	records, err := HpcrGetAttestationRecordsWithOptions(data, privateKey, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseAttestationRecords(records)
+}
+
+// Get - function to get sha256 of entry by name
+func (r *AttestationRecords) Get(name string) (string, bool) {
+	for _, entry := range r.Entries {
+		if entry.Name == name {
+			return entry.Sha256, true
+		}
+	}
+
+	return "", false
+}
+
+// Names - function to get names of entries in order of records
+func (r *AttestationRecords) Names() []string {
+	names := make([]string, 0, len(r.Entries))
+	for _, entry := range r.Entries {
+		names = append(names, entry.Name)
+	}
+
+	return names
+}
+
+// ToJson - function to convert attestation records to JSON
+func (r *AttestationRecords) ToJson() (string, error) {
+	recordsJson, err := json.Marshal(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal attestation records to JSON - %v", err)
+	}
+
+	return string(recordsJson), nil
+}
+
+// String - function to format attestation records in se-checksums.txt format
+func (r *AttestationRecords) String() string {
+	var builder strings.Builder
+
+	builder.WriteString(r.Version + "\n")
+	builder.WriteString(fmt.Sprintf("%s %s/%s/%s\n", machineLinePrefix, r.Machine.Type, r.Machine.Plant, r.Machine.Serial))
+
+	for _, entry := range r.Entries {
+		builder.WriteString(entry.Sha256 + " " + entry.Name + "\n")
+	}
+
+	return builder.String()
+}
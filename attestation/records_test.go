@@ -0,0 +1,102 @@
+package attestation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+	prov "github.com/Sashwat-K/lib-hpcr/common/provider"
+)
+
+const (
+	sampleAttestationRecords = `24.3.3
+Machine Type/Plant/Serial: 8562/02/4C598
+0bf377eea1136f03cbd5fbb1f2d12bb9a40a3e9f97a8438196b549fdafd0786e root.tar.gz
+4330056bbbf5d53d48fa167f0f46bf4501b8ee42bd926e1a8b6c25d210cd1baf baseimage
+dc81220e9f9dad11c5ae12cd890a2c7c68ea5fa67399f49a7f9ce17408fc8c64 /dev/disk/by-label/cidata
+1b8de43e9b9cecf0a050f238ce10222ac43ac782242e8a88728f872c795a2c9c cidata/meta-data
+3879908b724ff6c8b49a63dbe4b741453f6bd19533518f4aa2168e739fe3d0ea cidata/user-data
+3bee754bb0c58bb691242b0d1787bc5b1f71d22885d9444b581e4b51adecac0d cidata/vendor-data
+089370ef324cc44aa1b697db5de984cd6a3bc56c4b6977363cdb3aa2208497f1 attestationPublicKey
+37f04773240e09221cf4c07e1f237bf1cb601d4d0e87696736fd06855149623e env
+9b10f72e8704f4e2e652251a6c8da5d922722fcb03bc58e1aa7a6a787517cd74 workload
+`
+	sampleBaseImageSha256 = "4330056bbbf5d53d48fa167f0f46bf4501b8ee42bd926e1a8b6c25d210cd1baf"
+)
+
+// Testcase to check if ParseAttestationRecords() parses version, machine and entries in order
+func TestParseAttestationRecords(t *testing.T) {
+	result, err := ParseAttestationRecords(sampleAttestationRecords)
+	if err != nil {
+		t.Errorf("failed to parse attestation records - %v", err)
+	}
+
+	assert.Equal(t, result.Version, "24.3.3")
+	assert.Equal(t, result.Machine, Machine{Type: "8562", Plant: "02", Serial: "4C598"})
+	assert.Len(t, result.Entries, 9)
+	assert.Equal(t, result.Entries[1], AttestationEntry{Name: EntryBaseImage, Sha256: sampleBaseImageSha256})
+
+	_, err = ParseAttestationRecords("24.3.3\n8562/02/4C598\n")
+	assert.ErrorContains(t, err, "line 2")
+
+	_, err = ParseAttestationRecords(sampleAttestationRecords + "abc workload\n")
+	assert.ErrorContains(t, err, "line 12")
+
+	_, err = ParseAttestationRecords(sampleAttestationRecords + sampleBaseImageSha256 + " baseimage\n")
+	assert.ErrorContains(t, err, "duplicate entry baseimage")
+}
+
+// Testcase to check if HpcrGetAttestationRecordsTyped() decrypts and parses attestation records
+func TestHpcrGetAttestationRecordsTyped(t *testing.T) {
+	encChecksum, err := gen.ReadDataFromFile(encryptedChecksumPath)
+	if err != nil {
+		t.Errorf("failed to get encrypted checksum - %v", err)
+	}
+
+	privateKeyData, err := gen.ReadDataFromFile(privateKeyPath)
+	if err != nil {
+		t.Errorf("failed to get private key - %v", err)
+	}
+
+	result, err := HpcrGetAttestationRecordsTyped(encChecksum, privateKeyData, Options{Provider: prov.NativeProvider{}})
+	if err != nil {
+		t.Errorf("failed to decrypt attestation records - %v", err)
+	}
+
+	assert.Equal(t, result.String(), sampleAttestationRecords)
+}
+
+// Testcase to check if Get() and Names() look up entries
+func TestAttestationRecordsLookup(t *testing.T) {
+	result, err := ParseAttestationRecords(sampleAttestationRecords)
+	if err != nil {
+		t.Errorf("failed to parse attestation records - %v", err)
+	}
+
+	sha256, ok := result.Get(EntryBaseImage)
+	assert.True(t, ok)
+	assert.Equal(t, sha256, sampleBaseImageSha256)
+
+	_, ok = result.Get("missing")
+	assert.False(t, ok)
+
+	assert.Equal(t, result.Names()[0], EntryRootTarGz)
+	assert.Equal(t, result.Names()[8], EntryWorkload)
+}
+
+// Testcase to check if ToJson() exports attestation records
+func TestAttestationRecordsToJson(t *testing.T) {
+	result, err := ParseAttestationRecords(sampleAttestationRecords)
+	if err != nil {
+		t.Errorf("failed to parse attestation records - %v", err)
+	}
+
+	recordsJson, err := result.ToJson()
+	if err != nil {
+		t.Errorf("failed to convert attestation records to JSON - %v", err)
+	}
+
+	assert.Contains(t, recordsJson, `"machine":{"type":"8562","plant":"02","serial":"4C598"}`)
+	assert.Contains(t, recordsJson, `{"name":"baseimage","sha256":"`+sampleBaseImageSha256+`"}`)
+}
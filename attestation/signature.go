@@ -0,0 +1,113 @@
+package attestation
+
+import (
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+)
+
+type (
+	// CertificateChain - PEM certificates to verify signature of attestation records
+	CertificateChain struct {
+		// Certificate - IBM attestation certificate
+		Certificate string
+		// Intermediates - IBM intermediate certificates (one or more PEM blocks)
+		Intermediates string
+		// Roots - IBM root certificates (one or more PEM blocks)
+		Roots string
+	}
+)
+
+// HpcrVerifyAttestationSignature - function to verify signature (se-signature.bin) of decrypted attestation records (se-checksums.txt)
+// The attestation certificate must be valid now and be issued through intermediates by one of the roots
+func HpcrVerifyAttestationSignature(records, signature string, chain CertificateChain) error {
+	return verifyAttestationSignature(records, signature, chain, time.Now())
+}
+
+// HpcrGetAttestationRecordsVerified - function to decrypt attestation records and return them only if their signature is valid
+func HpcrGetAttestationRecordsVerified(data, signature, privateKey string, chain CertificateChain, opts Options) (string, error) {
+	records, err := HpcrGetAttestationRecordsWithOptions(data, privateKey, opts)
+	if err != nil {
+		return "", err
+	}
+
+	err = HpcrVerifyAttestationSignature(records, signature, chain)
+	if err != nil {
+		return "", err
+	}
+
+	return records, nil
+}
+
+// verifyAttestationSignature - function to verify attestation certificate at given time and signature of records
+func verifyAttestationSignature(records, signature string, chain CertificateChain, now time.Time) error {
+	if gen.CheckIfEmpty(records, signature, chain.Certificate, chain.Roots) {
+		return fmt.Errorf(missingParameterErrStatement)
+	}
+
+	certificates, err := gen.ParseCertificates(chain.Certificate)
+	if err != nil {
+		return fmt.Errorf("failed to parse attestation certificate - %v", err)
+	}
+	certificate := certificates[0]
+
+	if now.Before(certificate.NotBefore) {
+		return fmt.Errorf("attestation certificate is not valid before %s", certificate.NotBefore.UTC().Format(time.RFC3339))
+	}
+
+	if now.After(certificate.NotAfter) {
+		return fmt.Errorf("attestation certificate expired on %s", certificate.NotAfter.UTC().Format(time.RFC3339))
+	}
+
+	roots, err := certificatePool(chain.Roots)
+	if err != nil {
+		return fmt.Errorf("failed to parse root certificate - %v", err)
+	}
+
+	intermediates := x509.NewCertPool()
+	if chain.Intermediates != "" {
+		intermediates, err = certificatePool(chain.Intermediates)
+		if err != nil {
+			return fmt.Errorf("failed to parse intermediate certificate - %v", err)
+		}
+	}
+
+	_, err = certificate.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return fmt.Errorf("attestation certificate is not issued by root certificate - %v", err)
+	}
+
+	algorithm := x509.SHA256WithRSA
+	if certificate.PublicKeyAlgorithm == x509.ECDSA {
+		algorithm = x509.ECDSAWithSHA256
+	}
+
+	err = certificate.CheckSignature(algorithm, []byte(records), []byte(signature))
+	if err != nil {
+		return fmt.Errorf("signature of attestation records is invalid - %v", err)
+	}
+
+	return nil
+}
+
+// certificatePool - function to create pool of PEM certificates
+func certificatePool(certificatesPem string) (*x509.CertPool, error) {
+	certificates, err := gen.ParseCertificates(certificatesPem)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	for _, certificate := range certificates {
+		pool.AddCert(certificate)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,98 @@
+package attestation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+	prov "github.com/Sashwat-K/lib-hpcr/common/provider"
+)
+
+const (
+	signaturePath               = "../samples/attestation/se-signature.bin"
+	attestationCertificatePath  = "../samples/attestation/attestation.crt"
+	intermediateCertificatePath = "../samples/attestation/intermediate.crt"
+	rootCertificatePath         = "../samples/attestation/root.crt"
+	sampleOtherRootCertificate  = "../samples/encrypt/certificate.crt"
+)
+
+// attestationChain - function to read sample signature and certificate chain
+func attestationChain(t *testing.T) (string, CertificateChain) {
+	var chain CertificateChain
+
+	signature, err := gen.ReadDataFromFile(signaturePath)
+	if err != nil {
+		t.Fatalf("failed to read signature - %v", err)
+	}
+
+	for path, value := range map[string]*string{
+		attestationCertificatePath:  &chain.Certificate,
+		intermediateCertificatePath: &chain.Intermediates,
+		rootCertificatePath:         &chain.Roots,
+	} {
+		*value, err = gen.ReadDataFromFile(path)
+		if err != nil {
+			t.Fatalf("failed to read certificate - %v", err)
+		}
+	}
+
+	return signature, chain
+}
+
+// Testcase to check if HpcrVerifyAttestationSignature() verifies signature and certificate chain
+func TestHpcrVerifyAttestationSignature(t *testing.T) {
+	signature, chain := attestationChain(t)
+
+	err := HpcrVerifyAttestationSignature(sampleAttestationRecords, signature, chain)
+	if err != nil {
+		t.Errorf("failed to verify attestation signature - %v", err)
+	}
+
+	err = HpcrVerifyAttestationSignature(sampleAttestationRecords+sampleBaseImageSha256+" forged\n", signature, chain)
+	assert.ErrorContains(t, err, "signature of attestation records is invalid")
+
+	withoutIntermediates := chain
+	withoutIntermediates.Intermediates = ""
+	err = HpcrVerifyAttestationSignature(sampleAttestationRecords, signature, withoutIntermediates)
+	assert.ErrorContains(t, err, "not issued by root certificate")
+
+	otherRoot, err := gen.ReadDataFromFile(sampleOtherRootCertificate)
+	if err != nil {
+		t.Errorf("failed to read certificate - %v", err)
+	}
+
+	withOtherRoot := chain
+	withOtherRoot.Roots = otherRoot
+	err = HpcrVerifyAttestationSignature(sampleAttestationRecords, signature, withOtherRoot)
+	assert.ErrorContains(t, err, "not issued by root certificate")
+
+	err = verifyAttestationSignature(sampleAttestationRecords, signature, chain, time.Now().AddDate(200, 0, 0))
+	assert.ErrorContains(t, err, "attestation certificate expired")
+}
+
+// Testcase to check if HpcrGetAttestationRecordsVerified() returns decrypted attestation records with valid signature
+func TestHpcrGetAttestationRecordsVerified(t *testing.T) {
+	signature, chain := attestationChain(t)
+
+	encChecksum, err := gen.ReadDataFromFile(encryptedChecksumPath)
+	if err != nil {
+		t.Errorf("failed to get encrypted checksum - %v", err)
+	}
+
+	privateKeyData, err := gen.ReadDataFromFile(privateKeyPath)
+	if err != nil {
+		t.Errorf("failed to get private key - %v", err)
+	}
+
+	result, err := HpcrGetAttestationRecordsVerified(encChecksum, signature, privateKeyData, chain, Options{Provider: prov.NativeProvider{}})
+	if err != nil {
+		t.Errorf("failed to get verified attestation records - %v", err)
+	}
+
+	assert.Equal(t, result, sampleAttestationRecords)
+
+	_, err = HpcrGetAttestationRecordsVerified(encChecksum, signature[1:], privateKeyData, chain, Options{Provider: prov.NativeProvider{}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,96 @@
+package attestation
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+)
+
+// status of verified entries
+const (
+	VerificationMatch    = "match"
+	VerificationMismatch = "mismatch"
+	VerificationMissing  = "missing"
+	// VerificationUnverifiable - plain (not encrypted) section, the bytes HPCR measures for it are not known
+	VerificationUnverifiable = "unverifiable"
+)
+
+var (
+	// contractEntries - top level keys of contract that are measured in attestation records
+	contractEntries = []string{EntryWorkload, EntryEnv, EntryAttestationPublicKey}
+)
+
+type (
+	// EntryVerification - result of comparing expected sha256 of contract entry with attestation records
+	EntryVerification struct {
+		Name     string `json:"name"`
+		Expected string `json:"expected"`
+		Actual   string `json:"actual,omitempty"`
+		Status   string `json:"status"`
+	}
+)
+
+// HpcrVerifyAttestationRecords - function to compare attestation records with sha256 of contract (cidata/user-data) and of its sections
+// The contract must be exactly the user data the instance was started with (eg: output of HpcrContractSignedEncrypted)
+// cidata/user-data is the sha256 of the whole contract and encrypted sections are the sha256 of their hyper-protect-basic string,
+// plain sections are reported as unverifiable
+func HpcrVerifyAttestationRecords(contract, records string) ([]EntryVerification, error) {
+	if gen.CheckIfEmpty(contract, records) {
+		return nil, fmt.Errorf(missingParameterErrStatement)
+	}
+
+	attestationRecords, err := ParseAttestationRecords(records)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse attestation records - %v", err)
+	}
+
+	var contractMap map[string]interface{}
+	err = yaml.Unmarshal([]byte(contract), &contractMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal contract - %v", err)
+	}
+
+	expected := []EntryVerification{{Name: EntryUserData, Expected: gen.GenerateSha256(contract)}}
+
+	for _, key := range contractEntries {
+		switch value := contractMap[key].(type) {
+		case nil:
+		case string:
+			expected = append(expected, EntryVerification{Name: key, Expected: gen.GenerateSha256(value)})
+		case map[string]interface{}:
+			expected = append(expected, EntryVerification{Name: key, Status: VerificationUnverifiable})
+		default:
+			return nil, fmt.Errorf("%s has unexpected type %T", key, value)
+		}
+	}
+
+	for index, entry := range expected {
+		actual, ok := attestationRecords.Get(entry.Name)
+
+		switch {
+		case entry.Status == VerificationUnverifiable:
+			expected[index].Actual = actual
+		case !ok:
+			expected[index].Status = VerificationMissing
+		case actual == entry.Expected:
+			expected[index].Actual, expected[index].Status = actual, VerificationMatch
+		default:
+			expected[index].Actual, expected[index].Status = actual, VerificationMismatch
+		}
+	}
+
+	return expected, nil
+}
+
+// VerificationPassed - function to check if all entries match, unverifiable entries fail the check
+func VerificationPassed(results []EntryVerification) bool {
+	for _, result := range results {
+		if result.Status != VerificationMatch {
+			return false
+		}
+	}
+
+	return len(results) > 0
+}
@@ -0,0 +1,182 @@
+package attestation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+	"github.com/Sashwat-K/lib-hpcr/contract"
+)
+
+const (
+	// synthetic fixture, not from an HPCR instance: contract generated by contract.HpcrContractSignedEncrypted() and
+	// records calculated for it with sha256sum, real records are in se-checksums.txt.enc but their contract is not available
+	sampleVerifyContractPath = "../samples/attestation/synthetic-contract.yaml"
+	sampleVerifyRecordsPath  = "../samples/attestation/synthetic-se-checksums.txt"
+	samplePrivateKeyPath     = "../samples/encrypt/private.pem"
+	samplePublicKeyPath      = "../samples/encrypt/public.pem"
+
+	sampleVerifyWorkload = "hyper-protect-basic.d29ya2xvYWQ=.d29ya2xvYWQ="
+	sampleVerifyEnv      = "hyper-protect-basic.ZW52.ZW52"
+	sampleVerifyContract = "env: " + sampleVerifyEnv + "\nworkload: " + sampleVerifyWorkload + "\n"
+)
+
+// attestationRecordsOf - function to generate attestation records with given entry lines after the sample header
+func attestationRecordsOf(entries ...string) string {
+	records := "24.3.3\nMachine Type/Plant/Serial: 8562/02/4C598\n"
+	for i := 0; i+1 < len(entries); i += 2 {
+		records += gen.GenerateSha256(entries[i+1]) + " " + entries[i] + "\n"
+	}
+
+	return records
+}
+
+// Testcase to check if HpcrVerifyAttestationRecords() reports match, mismatch and missing entries
+func TestHpcrVerifyAttestationRecords(t *testing.T) {
+	records := attestationRecordsOf(EntryUserData, sampleVerifyContract, EntryEnv, sampleVerifyEnv, EntryWorkload, sampleVerifyWorkload)
+
+	result, err := HpcrVerifyAttestationRecords(sampleVerifyContract, records)
+	if err != nil {
+		t.Errorf("failed to verify attestation records - %v", err)
+	}
+
+	assert.Len(t, result, 3)
+	assert.Equal(t, result[0], EntryVerification{Name: EntryUserData, Expected: gen.GenerateSha256(sampleVerifyContract), Actual: gen.GenerateSha256(sampleVerifyContract), Status: VerificationMatch})
+	assert.True(t, VerificationPassed(result))
+
+	records = attestationRecordsOf(EntryUserData, sampleVerifyContract+"\n", EntryWorkload, sampleVerifyWorkload)
+
+	result, err = HpcrVerifyAttestationRecords(sampleVerifyContract, records)
+	if err != nil {
+		t.Errorf("failed to verify attestation records - %v", err)
+	}
+
+	assert.Equal(t, result[0].Status, VerificationMismatch)
+	assert.Equal(t, result[1].Status, VerificationMatch)
+	assert.Equal(t, result[2], EntryVerification{Name: EntryEnv, Expected: gen.GenerateSha256(sampleVerifyEnv), Status: VerificationMissing})
+	assert.False(t, VerificationPassed(result))
+
+	_, err = HpcrVerifyAttestationRecords(sampleVerifyContract, "24.3.3\n")
+	assert.Error(t, err)
+}
+
+// Testcase to check if HpcrVerifyAttestationRecords() finds all contract entries in real attestation records of an HPCR instance
+func TestHpcrVerifyAttestationRecordsReal(t *testing.T) {
+	encChecksum, err := gen.ReadDataFromFile(encryptedChecksumPath)
+	if err != nil {
+		t.Errorf("failed to get encrypted checksum - %v", err)
+	}
+
+	privateKeyData, err := gen.ReadDataFromFile(privateKeyPath)
+	if err != nil {
+		t.Errorf("failed to get private key - %v", err)
+	}
+
+	records, err := HpcrGetAttestationRecords(encChecksum, privateKeyData)
+	if err != nil {
+		t.Errorf("failed to decrypt attestation records - %v", err)
+	}
+
+	contractYaml := "attestationPublicKey: " + sampleVerifyEnv + "\nenv: " + sampleVerifyEnv + "\nworkload: " + sampleVerifyWorkload + "\n"
+
+	result, err := HpcrVerifyAttestationRecords(contractYaml, records)
+	if err != nil {
+		t.Errorf("failed to verify attestation records - %v", err)
+	}
+
+	// contract of the instance is not available, so entries are found but don't match
+	assert.Len(t, result, 4)
+	for _, entry := range result {
+		assert.Equal(t, entry.Status, VerificationMismatch, entry.Name)
+	}
+}
+
+// Testcase to check if HpcrVerifyAttestationRecords() verifies contract built with ContractBuilder and attestation public key
+func TestHpcrVerifyAttestationRecordsBuilder(t *testing.T) {
+	publicKey, err := gen.ReadDataFromFile(samplePublicKeyPath)
+	if err != nil {
+		t.Fatalf("failed to read public key - %v", err)
+	}
+
+	privateKey, err := gen.ReadDataFromFile(samplePrivateKeyPath)
+	if err != nil {
+		t.Fatalf("failed to read private key - %v", err)
+	}
+
+	typedContract, err := contract.NewContractBuilder().
+		WithComposeArchive("kdsbfoijdfojsnbo").
+		WithLogDNA("syslog-a.eu-de.logging.cloud.ibm.com", "ab00e3c09p1d4ff7fff9f04c12183413").
+		WithAttestationPublicKey(publicKey).
+		Build()
+	if err != nil {
+		t.Fatalf("failed to build contract - %v", err)
+	}
+
+	finalContract, _, _, err := contract.HpcrContractSignedEncryptedTyped(typedContract, "", privateKey, contract.Options{})
+	if err != nil {
+		t.Fatalf("failed to generate contract - %v", err)
+	}
+
+	var contractMap map[string]string
+	err = yaml.Unmarshal([]byte(finalContract), &contractMap)
+	if err != nil {
+		t.Fatalf("failed to unmarshal contract - %v", err)
+	}
+
+	records := attestationRecordsOf(EntryUserData, finalContract, EntryAttestationPublicKey, contractMap["attestationPublicKey"], EntryEnv, contractMap["env"], EntryWorkload, contractMap["workload"])
+
+	result, err := HpcrVerifyAttestationRecords(finalContract, records)
+	if err != nil {
+		t.Errorf("failed to verify attestation records - %v", err)
+	}
+
+	assert.Len(t, result, 4)
+	assert.Equal(t, result[3].Name, EntryAttestationPublicKey)
+	assert.True(t, VerificationPassed(result))
+}
+
+// Testcase to check if HpcrVerifyAttestationRecords() matches synthetic records calculated with sha256sum for contract generated by HpcrContractSignedEncrypted()
+func TestHpcrVerifyAttestationRecordsFixture(t *testing.T) {
+	contract, err := gen.ReadDataFromFile(sampleVerifyContractPath)
+	if err != nil {
+		t.Fatalf("failed to read contract - %v", err)
+	}
+
+	records, err := gen.ReadDataFromFile(sampleVerifyRecordsPath)
+	if err != nil {
+		t.Fatalf("failed to read attestation records - %v", err)
+	}
+
+	result, err := HpcrVerifyAttestationRecords(contract, records)
+	if err != nil {
+		t.Errorf("failed to verify attestation records - %v", err)
+	}
+
+	assert.Len(t, result, 3)
+	assert.Equal(t, result[0].Actual, "6d9e325d05c38b1fbbfa722f9b62521854d41715db964a9289e0597f49fa6faf")
+	assert.True(t, VerificationPassed(result))
+}
+
+// Testcase to check if HpcrVerifyAttestationRecords() reports plain sections as unverifiable
+func TestHpcrVerifyAttestationRecordsPlain(t *testing.T) {
+	contract := "env: " + sampleVerifyEnv + "\nworkload:\n  type: workload\n"
+	records := attestationRecordsOf(EntryUserData, contract, EntryEnv, sampleVerifyEnv, EntryWorkload, "type: workload\n")
+
+	result, err := HpcrVerifyAttestationRecords(contract, records)
+	if err != nil {
+		t.Errorf("failed to verify attestation records - %v", err)
+	}
+
+	assert.Equal(t, result[1], EntryVerification{Name: EntryWorkload, Actual: gen.GenerateSha256("type: workload\n"), Status: VerificationUnverifiable})
+	assert.Equal(t, result[2].Status, VerificationMatch)
+	assert.False(t, VerificationPassed(result))
+}
+
+// Testcase to check if VerificationPassed() requires all entries to match
+func TestVerificationPassed(t *testing.T) {
+	assert.False(t, VerificationPassed(nil))
+	assert.True(t, VerificationPassed([]EntryVerification{{Status: VerificationMatch}}))
+	assert.False(t, VerificationPassed([]EntryVerification{{Status: VerificationMatch}, {Status: VerificationMissing}}))
+}
@@ -1,10 +1,17 @@
 package certificate
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"math/big"
 	"strings"
 	"text/template"
+	"time"
 
 	gen "github.com/Sashwat-K/lib-hpcr/common/general"
 )
@@ -12,6 +19,9 @@ import (
 const (
 	defaultEncCertUrlTemplate    = "https://cloud.ibm.com/media/docs/downloads/hyper-protect-container-runtime/ibm-hyper-protect-container-runtime-{{.Major}}-{{.Minor}}-s390x-{{.Patch}}-encrypt.crt"
 	missingParameterErrStatement = "required parameter is missing"
+
+	// testEncryptionKeySize - key size of HPCR encryption certificate
+	testEncryptionKeySize = 4096
 )
 
 type CertSpec struct {
@@ -76,3 +86,54 @@ func HpcrDownloadEncryptionCertificates(versionList []string) (string, error) {
 
 	return string(jsonBytes), nil
 }
+
+// HpcrGenerateTestEncryptionCertificate - function to generate self-signed encryption certificate and private key for local testing
+// The certificate has the same shape as HPCR encryption certificate, so contracts encrypted with it can be decrypted with the private key
+func HpcrGenerateTestEncryptionCertificate(expiryDays int) (string, string, error) {
+	if expiryDays <= 0 {
+		return "", "", fmt.Errorf("expiry days must be greater than 0")
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, testEncryptionKeySize)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate private key - %v", err)
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate serial number - %v", err)
+	}
+
+	notBefore := time.Now().Add(-time.Minute)
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			Country:            []string{"IN"},
+			Province:           []string{"KA"},
+			Locality:           []string{"Bangalore"},
+			Organization:       []string{"Test"},
+			OrganizationalUnit: []string{"IBM Z Hybrid Cloud Platform"},
+			CommonName:         "Hyper Protect Container Runtime Contract Encryption (test)",
+		},
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.AddDate(0, 0, expiryDays),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDataEncipherment,
+		BasicConstraintsValid: true,
+		SignatureAlgorithm:    x509.SHA512WithRSA,
+	}
+
+	certificateDer, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create certificate - %v", err)
+	}
+
+	privateKeyDer, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal private key - %v", err)
+	}
+
+	certificatePem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificateDer})
+	privateKeyPem := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKeyDer})
+
+	return string(certificatePem), string(privateKeyPem), nil
+}
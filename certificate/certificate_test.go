@@ -1,9 +1,14 @@
 package certificate
 
 import (
+	"crypto/rsa"
+	"crypto/x509"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+	prov "github.com/Sashwat-K/lib-hpcr/common/provider"
 )
 
 var (
@@ -56,3 +61,36 @@ func TestCombined(t *testing.T) {
 
 	assert.Equal(t, key, "1.0.15")
 }
+
+// Testcase to check if HpcrGenerateTestEncryptionCertificate() generates certificate whose private key decrypts data
+func TestHpcrGenerateTestEncryptionCertificate(t *testing.T) {
+	certificate, privateKey, err := HpcrGenerateTestEncryptionCertificate(30)
+	if err != nil {
+		t.Errorf("failed to generate test encryption certificate - %v", err)
+	}
+
+	certificates, err := gen.ParseCertificates(certificate)
+	if err != nil {
+		t.Errorf("failed to parse certificate - %v", err)
+	}
+
+	assert.Equal(t, 4096, certificates[0].PublicKey.(*rsa.PublicKey).N.BitLen())
+	assert.Equal(t, x509.KeyUsageKeyEncipherment|x509.KeyUsageDataEncipherment, certificates[0].KeyUsage)
+
+	cryptoProvider := prov.NativeProvider{}
+
+	encryptedPassword, err := cryptoProvider.EncryptPassword("password", certificate)
+	if err != nil {
+		t.Errorf("failed to encrypt password - %v", err)
+	}
+
+	result, err := cryptoProvider.DecryptPassword(encryptedPassword, privateKey)
+	if err != nil {
+		t.Errorf("failed to decrypt password - %v", err)
+	}
+
+	assert.Equal(t, "password", result)
+
+	_, _, err = HpcrGenerateTestEncryptionCertificate(0)
+	assert.Error(t, err)
+}
@@ -19,7 +19,7 @@ func DecryptPassword(base64EncryptedData, privateKey string) (string, error) {
 		return "", fmt.Errorf("failed to decode Base64 - %v", err)
 	}
 
-	encryptedDataPath, err := gen.CreateTempFile(decodedEncryptedData)
+	encryptedDataPath, err := gen.CreateBinaryTempFile(decodedEncryptedData)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate temp file - %v", err)
 	}
@@ -56,7 +56,7 @@ func DecryptWorkload(password, encryptedWorkload string) (string, error) {
 		return "", fmt.Errorf("failed to decode base64 data - %v", err)
 	}
 
-	encryptedDataPath, err := gen.CreateTempFile(decodedEncryptedWorkload)
+	encryptedDataPath, err := gen.CreateBinaryTempFile(decodedEncryptedWorkload)
 	if err != nil {
 		return "", fmt.Errorf("failed to create temp file - %v", err)
 	}
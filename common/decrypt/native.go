@@ -0,0 +1,87 @@
+package decrypt
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rsa"
+	"fmt"
+
+	enc "github.com/Sashwat-K/lib-hpcr/common/encrypt"
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+)
+
+// DecryptPasswordNative - function to decrypt encrypted string with private key without openssl
+func DecryptPasswordNative(base64EncryptedData, privateKey string) (string, error) {
+	decodedEncryptedData, err := gen.DecodeBase64String(base64EncryptedData)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode Base64 - %v", err)
+	}
+
+	rsaKey, err := gen.ParseRsaPrivateKey(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse private key - %v", err)
+	}
+
+	result, err := rsa.DecryptPKCS1v15(nil, rsaKey, []byte(decodedEncryptedData))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt password - %v", err)
+	}
+
+	return string(result), nil
+}
+
+// DecryptWorkloadNative - function to decrypt workload using password without openssl
+func DecryptWorkloadNative(password, encryptedWorkload string) (string, error) {
+	decodedEncryptedWorkload, err := gen.DecodeBase64String(encryptedWorkload)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode base64 data - %v", err)
+	}
+
+	data := []byte(decodedEncryptedWorkload)
+	headerLen := len(enc.OpensslSaltHeader) + enc.OpensslSaltLen
+
+	if len(data) < headerLen || string(data[:len(enc.OpensslSaltHeader)]) != enc.OpensslSaltHeader {
+		return "", fmt.Errorf("encrypted data doesn't have %s header", enc.OpensslSaltHeader)
+	}
+
+	cipherText := data[headerLen:]
+	if len(cipherText) == 0 || len(cipherText)%aes.BlockSize != 0 {
+		return "", fmt.Errorf("encrypted data is not a multiple of the block size")
+	}
+
+	key, iv := enc.DeriveKeyIv(password, data[len(enc.OpensslSaltHeader):headerLen])
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to create cipher - %v", err)
+	}
+
+	plainText := make([]byte, len(cipherText))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plainText, cipherText)
+
+	result, err := pkcs7Unpad(plainText, aes.BlockSize)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt workload - %v", err)
+	}
+
+	return string(result), nil
+}
+
+// pkcs7Unpad - function to remove PKCS#7 padding
+func pkcs7Unpad(data []byte, blockSize int) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty data")
+	}
+
+	padding := int(data[len(data)-1])
+	if padding == 0 || padding > blockSize || padding > len(data) {
+		return nil, fmt.Errorf("bad decrypt")
+	}
+
+	if !bytes.Equal(data[len(data)-padding:], bytes.Repeat([]byte{byte(padding)}, padding)) {
+		return nil, fmt.Errorf("bad decrypt")
+	}
+
+	return data[:len(data)-padding], nil
+}
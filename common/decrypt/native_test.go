@@ -0,0 +1,158 @@
+package decrypt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	enc "github.com/Sashwat-K/lib-hpcr/common/encrypt"
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+)
+
+const (
+	simpleCertificatePath = "../../samples/encrypt/certificate.crt"
+	simplePrivateKeyPath  = "../../samples/encrypt/private.pem"
+	simpleContractPath    = "../../samples/simple_contract.yaml"
+)
+
+// Testcase to check if DecryptPasswordNative() is able to decrypt password encrypted by openssl
+func TestDecryptPasswordNative(t *testing.T) {
+	encChecksum, err := gen.ReadDataFromFile(encryptedChecksumPath)
+	if err != nil {
+		t.Errorf("failed to read encrypted checksum - %v", err)
+	}
+
+	encodedEncryptedPassword := strings.Split(encChecksum, ".")[1]
+
+	privateKeyData, err := gen.ReadDataFromFile(privateKeyPath)
+	if err != nil {
+		t.Errorf("failed to read private key - %v", err)
+	}
+
+	expected, err := DecryptPassword(encodedEncryptedPassword, privateKeyData)
+	if err != nil {
+		t.Errorf("failed to decrypt password - %v", err)
+	}
+
+	result, err := DecryptPasswordNative(encodedEncryptedPassword, privateKeyData)
+	if err != nil {
+		t.Errorf("failed to decrypt password - %v", err)
+	}
+
+	assert.Equal(t, expected, result)
+}
+
+// Testcase to check if DecryptWorkloadNative() is able to decrypt workload encrypted by openssl
+func TestDecryptWorkloadNative(t *testing.T) {
+	encChecksum, err := gen.ReadDataFromFile(encryptedChecksumPath)
+	if err != nil {
+		t.Errorf("failed to read encrypted checksum - %v", err)
+	}
+
+	encodedEncryptedPassword := strings.Split(encChecksum, ".")[1]
+	encodedEncryptedData := strings.Split(encChecksum, ".")[2]
+
+	privateKeyData, err := gen.ReadDataFromFile(privateKeyPath)
+	if err != nil {
+		t.Errorf("failed to read private key - %v", err)
+	}
+
+	password, err := DecryptPasswordNative(encodedEncryptedPassword, privateKeyData)
+	if err != nil {
+		t.Errorf("failed to decrypt password - %v", err)
+	}
+
+	result, err := DecryptWorkloadNative(password, encodedEncryptedData)
+	if err != nil {
+		t.Errorf("failed to decrypt workload - %v", err)
+	}
+
+	assert.Contains(t, result, sampleAttestationRecordKey)
+}
+
+// Testcase to check if data encrypted by openssl can be decrypted natively
+func TestOpensslToNative(t *testing.T) {
+	contract, certificate, privateKey := nativeTestData(t)
+
+	password, err := enc.RandomPasswordGenerator()
+	if err != nil {
+		t.Errorf("failed to generate random password - %v", err)
+	}
+
+	encryptedPassword, err := enc.EncryptPassword(password, certificate)
+	if err != nil {
+		t.Errorf("failed to encrypt password - %v", err)
+	}
+
+	encryptedContract, err := enc.EncryptString(password, contract)
+	if err != nil {
+		t.Errorf("failed to encrypt string - %v", err)
+	}
+
+	decryptedPassword, err := DecryptPasswordNative(encryptedPassword, privateKey)
+	if err != nil {
+		t.Errorf("failed to decrypt password - %v", err)
+	}
+
+	result, err := DecryptWorkloadNative(decryptedPassword, encryptedContract)
+	if err != nil {
+		t.Errorf("failed to decrypt workload - %v", err)
+	}
+
+	assert.Equal(t, password, decryptedPassword)
+	assert.Equal(t, strings.TrimSpace(contract), result)
+}
+
+// Testcase to check if data encrypted natively can be decrypted by openssl
+func TestNativeToOpenssl(t *testing.T) {
+	contract, certificate, privateKey := nativeTestData(t)
+
+	password, err := enc.RandomPasswordGeneratorNative()
+	if err != nil {
+		t.Errorf("failed to generate random password - %v", err)
+	}
+
+	encryptedPassword, err := enc.EncryptPasswordNative(password, certificate)
+	if err != nil {
+		t.Errorf("failed to encrypt password - %v", err)
+	}
+
+	encryptedContract, err := enc.EncryptStringNative(password, contract)
+	if err != nil {
+		t.Errorf("failed to encrypt string - %v", err)
+	}
+
+	decryptedPassword, err := DecryptPassword(encryptedPassword, privateKey)
+	if err != nil {
+		t.Errorf("failed to decrypt password - %v", err)
+	}
+
+	result, err := DecryptWorkload(decryptedPassword, encryptedContract)
+	if err != nil {
+		t.Errorf("failed to decrypt workload - %v", err)
+	}
+
+	assert.Equal(t, password, decryptedPassword)
+	assert.Equal(t, strings.TrimSpace(contract), result)
+}
+
+// nativeTestData - function to read contract, encryption certificate and private key for round trip tests
+func nativeTestData(t *testing.T) (string, string, string) {
+	contract, err := gen.ReadDataFromFile(simpleContractPath)
+	if err != nil {
+		t.Errorf("failed to read contract - %v", err)
+	}
+
+	certificate, err := gen.ReadDataFromFile(simpleCertificatePath)
+	if err != nil {
+		t.Errorf("failed to read encryption certificate - %v", err)
+	}
+
+	privateKey, err := gen.ReadDataFromFile(simplePrivateKeyPath)
+	if err != nil {
+		t.Errorf("failed to read private key - %v", err)
+	}
+
+	return contract, certificate, privateKey
+}
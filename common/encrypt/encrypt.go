@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/Sashwat-K/lib-hpcr/common/envelope"
 	gen "github.com/Sashwat-K/lib-hpcr/common/general"
 )
 
@@ -119,7 +120,7 @@ func EncryptString(password, section string) (string, error) {
 
 // EncryptFinalStr - function to get final encrypted section
 func EncryptFinalStr(encryptedPassword, encryptedContract string) string {
-	return fmt.Sprintf("hyper-protect-basic.%s.%s", encryptedPassword, encryptedContract)
+	return envelope.Envelope{Password: encryptedPassword, Data: encryptedContract}.String()
 }
 
 // CreateSigningCert - function to generate Signing Certificate
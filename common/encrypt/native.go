@@ -0,0 +1,143 @@
+package encrypt
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+)
+
+const (
+	// OpensslSaltHeader - magic header written by `openssl enc` in front of the salt
+	OpensslSaltHeader = "Salted__"
+	// OpensslSaltLen - length of salt used by `openssl enc`
+	OpensslSaltLen = 8
+	// OpensslPbkdf2Iter - default iteration count of `openssl enc -pbkdf2`
+	OpensslPbkdf2Iter = 10000
+
+	aesIvLen = aes.BlockSize
+)
+
+// GeneratePublicKeyNative - function to generate public key from private key without openssl
+func GeneratePublicKeyNative(privateKey string) (string, error) {
+	rsaKey, err := gen.ParseRsaPrivateKey(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse private key - %v", err)
+	}
+
+	publicKeyDer, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal public key - %v", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyDer})), nil
+}
+
+// RandomPasswordGeneratorNative - function to generate random password without openssl
+func RandomPasswordGeneratorNative() (string, error) {
+	password := make([]byte, keylen)
+
+	for i := range password {
+		// `openssl enc -pass stdin` stops reading the password at the first newline or NUL byte,
+		// so both are excluded to keep the full password effective on either implementation
+		for password[i] == 0 || password[i] == '\n' {
+			_, err := rand.Read(password[i : i+1])
+			if err != nil {
+				return "", fmt.Errorf("failed to read random bytes - %v", err)
+			}
+		}
+	}
+
+	return string(password), nil
+}
+
+// EncryptPasswordNative - function to encrypt password without openssl
+func EncryptPasswordNative(password, cert string) (string, error) {
+	publicKey, err := gen.ParseRsaPublicKey(cert)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse encryption certificate - %v", err)
+	}
+
+	result, err := rsa.EncryptPKCS1v15(rand.Reader, publicKey, []byte(password))
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt password - %v", err)
+	}
+
+	return gen.EncodeToBase64(string(result)), nil
+}
+
+// EncryptStringNative - function to encrypt string without openssl
+func EncryptStringNative(password, section string) (string, error) {
+	salt := make([]byte, OpensslSaltLen)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate salt - %v", err)
+	}
+
+	key, iv := DeriveKeyIv(password, salt)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to create cipher - %v", err)
+	}
+
+	// the openssl path encrypts the trimmed data written to a temp file
+	plainText := pkcs7Pad([]byte(strings.TrimSpace(section)), aes.BlockSize)
+
+	cipherText := make([]byte, len(plainText))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(cipherText, plainText)
+
+	var result bytes.Buffer
+	result.WriteString(OpensslSaltHeader)
+	result.Write(salt)
+	result.Write(cipherText)
+
+	return gen.EncodeToBase64(result.String()), nil
+}
+
+// SignContractNative - function to sign encrypted contract without openssl
+func SignContractNative(encryptedWorkload, encryptedEnv, privateKey string) (string, error) {
+	rsaKey, err := gen.ParseRsaPrivateKey(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse private key - %v", err)
+	}
+
+	digest := sha256.Sum256([]byte(encryptedWorkload + encryptedEnv))
+
+	workloadEnvSignature, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign contract - %v", err)
+	}
+
+	return gen.EncodeToBase64(string(workloadEnvSignature)), nil
+}
+
+// DeriveKeyIv - function to derive AES key and IV the same way as `openssl enc -aes-256-cbc -pbkdf2 -pass stdin`
+func DeriveKeyIv(password string, salt []byte) ([]byte, []byte) {
+	passphrase := []byte(password)
+	if i := bytes.IndexAny(passphrase, "\n\x00"); i >= 0 {
+		passphrase = passphrase[:i]
+	}
+
+	keyIv := pbkdf2.Key(passphrase, salt, OpensslPbkdf2Iter, keylen+aesIvLen, sha256.New)
+
+	return keyIv[:keylen], keyIv[keylen:]
+}
+
+// pkcs7Pad - function to add PKCS#7 padding
+func pkcs7Pad(data []byte, blockSize int) []byte {
+	padding := blockSize - len(data)%blockSize
+
+	return append(data, bytes.Repeat([]byte{byte(padding)}, padding)...)
+}
@@ -0,0 +1,119 @@
+package encrypt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+)
+
+const (
+	simpleCertificatePath = "../../samples/encrypt/certificate.crt"
+)
+
+// Testcase to check if GeneratePublicKeyNative() generates the same public key as openssl
+func TestGeneratePublicKeyNative(t *testing.T) {
+	privateKey, err := gen.ReadDataFromFile(simplePrivateKeyPath)
+	if err != nil {
+		t.Errorf("failed to read private key - %v", err)
+	}
+
+	publicKey, err := gen.ReadDataFromFile(simplePublicKeyPath)
+	if err != nil {
+		t.Errorf("failed to read public key - %v", err)
+	}
+
+	result, err := GeneratePublicKeyNative(privateKey)
+	if err != nil {
+		t.Errorf("failed to generate public key - %v", err)
+	}
+
+	assert.Equal(t, result, publicKey)
+}
+
+// Testcase to check if RandomPasswordGeneratorNative() generates password usable by openssl
+func TestRandomPasswordGeneratorNative(t *testing.T) {
+	result, err := RandomPasswordGeneratorNative()
+	if err != nil {
+		t.Errorf("failed to generate random password - %v", err)
+	}
+
+	assert.Len(t, result, keylen)
+	assert.NotContains(t, result, "\n")
+	assert.NotContains(t, result, "\x00")
+}
+
+// Testcase to check if EncryptPasswordNative() is able to encrypt password
+func TestEncryptPasswordNative(t *testing.T) {
+	password, err := RandomPasswordGeneratorNative()
+	if err != nil {
+		t.Errorf("failed to generate random password - %v", err)
+	}
+
+	encryptCertificate, err := gen.ReadDataFromFile(simpleCertificatePath)
+	if err != nil {
+		t.Errorf("failed to read encryption certificate - %v", err)
+	}
+
+	result, err := EncryptPasswordNative(password, encryptCertificate)
+	if err != nil {
+		t.Errorf("failed to encrypt password - %v", err)
+	}
+
+	assert.NotEmpty(t, result, "Encrypted password did not get generated")
+}
+
+// Testcase to check if EncryptStringNative() generates data in openssl format
+func TestEncryptStringNative(t *testing.T) {
+	password, err := RandomPasswordGeneratorNative()
+	if err != nil {
+		t.Errorf("failed to generate random password - %v", err)
+	}
+
+	result, err := EncryptStringNative(password, sampleCsrDomain)
+	if err != nil {
+		t.Errorf("failed to encrypt string - %v", err)
+	}
+
+	decoded, err := gen.DecodeBase64String(result)
+	if err != nil {
+		t.Errorf("failed to decode base64 - %v", err)
+	}
+
+	assert.True(t, strings.HasPrefix(decoded, OpensslSaltHeader))
+}
+
+// Testcase to check if SignContractNative() generates the same signature as openssl
+func TestSignContractNative(t *testing.T) {
+	privateKey, err := gen.ReadDataFromFile(samplePrivateKeyPath)
+	if err != nil {
+		t.Errorf("failed to get private key - %v", err)
+	}
+
+	expected, err := SignContract("hyper-protect-basic.a.b", "hyper-protect-basic.c.d", privateKey)
+	if err != nil {
+		t.Errorf("failed to generate workload env signature - %v", err)
+	}
+
+	result, err := SignContractNative("hyper-protect-basic.a.b", "hyper-protect-basic.c.d", privateKey)
+	if err != nil {
+		t.Errorf("failed to generate workload env signature - %v", err)
+	}
+
+	assert.Equal(t, expected, result)
+}
+
+// Testcase to check if DeriveKeyIv() ignores data after newline like openssl
+func TestDeriveKeyIv(t *testing.T) {
+	salt := []byte(sampleCsrState[:OpensslSaltLen])
+
+	key1, iv1 := DeriveKeyIv("password", salt)
+	key2, iv2 := DeriveKeyIv("password\nignored", salt)
+
+	assert.Len(t, key1, keylen)
+	assert.Len(t, iv1, aesIvLen)
+	assert.Equal(t, key1, key2)
+	assert.Equal(t, iv1, iv2)
+}
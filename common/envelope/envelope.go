@@ -0,0 +1,97 @@
+package envelope
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// Scheme - name of HPCR encryption scheme, first segment of envelope
+	Scheme = "hyper-protect-basic"
+
+	separator    = "."
+	segmentCount = 3
+)
+
+// errors of Parse, wrapped with details and usable with errors.Is
+var (
+	ErrEmpty           = errors.New("envelope is empty")
+	ErrSegments        = errors.New("envelope must have scheme, password and data segments")
+	ErrScheme          = errors.New("envelope scheme is not " + Scheme)
+	ErrPasswordBase64  = errors.New("password of envelope is not valid base64")
+	ErrDataBase64      = errors.New("data of envelope is not valid base64")
+	ErrPasswordMissing = errors.New("password of envelope is empty")
+	ErrDataMissing     = errors.New("data of envelope is empty")
+)
+
+type (
+	// Envelope - encrypted value in hyper-protect-basic.<password>.<data> format
+	Envelope struct {
+		// Password - base64 of password encrypted with encryption certificate
+		Password string
+		// Data - base64 of data encrypted with password
+		Data string
+	}
+)
+
+// Parse - function to parse hyper-protect-basic.<password>.<data> string and check scheme and base64 of both parts
+func Parse(value string) (Envelope, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return Envelope{}, ErrEmpty
+	}
+
+	segments := strings.Split(value, separator)
+	if len(segments) != segmentCount {
+		return Envelope{}, fmt.Errorf("%w - found %d segment(s)", ErrSegments, len(segments))
+	}
+
+	if segments[0] != Scheme {
+		return Envelope{}, fmt.Errorf("%w - found %q", ErrScheme, segments[0])
+	}
+
+	result := Envelope{Password: segments[1], Data: segments[2]}
+
+	err := result.Validate()
+	if err != nil {
+		return Envelope{}, err
+	}
+
+	return result, nil
+}
+
+// IsEnvelope - function to check if value is a valid envelope
+func IsEnvelope(value string) bool {
+	_, err := Parse(value)
+	return err == nil
+}
+
+// Validate - function to check that password and data are not empty and are valid base64
+func (e Envelope) Validate() error {
+	if e.Password == "" {
+		return ErrPasswordMissing
+	}
+
+	if e.Data == "" {
+		return ErrDataMissing
+	}
+
+	_, err := base64.StdEncoding.DecodeString(e.Password)
+	if err != nil {
+		return fmt.Errorf("%w - %v", ErrPasswordBase64, err)
+	}
+
+	_, err = base64.StdEncoding.DecodeString(e.Data)
+	if err != nil {
+		return fmt.Errorf("%w - %v", ErrDataBase64, err)
+	}
+
+	return nil
+}
+
+// String - function to format envelope as hyper-protect-basic.<password>.<data>
+func (e Envelope) String() string {
+	return strings.Join([]string{Scheme, e.Password, e.Data}, separator)
+}
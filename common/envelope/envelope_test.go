@@ -0,0 +1,65 @@
+package envelope
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	samplePassword = "c2FzaHdhdA=="
+	sampleData     = "aw=="
+	sampleEnvelope = "hyper-protect-basic." + samplePassword + "." + sampleData
+)
+
+// Testcase to check if Parse() splits valid envelope and reports typed errors for malformed ones
+func TestParse(t *testing.T) {
+	result, err := Parse(" " + sampleEnvelope + "\n")
+	if err != nil {
+		t.Errorf("failed to parse envelope - %v", err)
+	}
+
+	assert.Equal(t, result, Envelope{Password: samplePassword, Data: sampleData})
+
+	for value, expected := range map[string]error{
+		"":                                      ErrEmpty,
+		"hyper-protect-basic":                   ErrSegments,
+		"hyper-protect-basic." + samplePassword: ErrSegments,
+		sampleEnvelope + ".extra":               ErrSegments,
+		"hyper-protect-plus." + samplePassword + "." + sampleData: ErrScheme,
+		"hyper-protect-basic.." + sampleData:                      ErrPasswordMissing,
+		"hyper-protect-basic." + samplePassword + ".":             ErrDataMissing,
+		"hyper-protect-basic.sashwat." + sampleData:               ErrPasswordBase64,
+		"hyper-protect-basic." + samplePassword + ".k":            ErrDataBase64,
+	} {
+		_, err := Parse(value)
+		assert.True(t, errors.Is(err, expected), "%q - expected %v, got %v", value, expected, err)
+	}
+}
+
+// Testcase to check if IsEnvelope() accepts only valid envelopes
+func TestIsEnvelope(t *testing.T) {
+	assert.True(t, IsEnvelope(sampleEnvelope))
+	assert.False(t, IsEnvelope("hyper-protect-basic.a.b"))
+}
+
+// Testcase to check if Validate() checks password and data of envelope
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Envelope{Password: samplePassword, Data: sampleData}.Validate())
+	assert.ErrorIs(t, Envelope{Password: samplePassword}.Validate(), ErrDataMissing)
+}
+
+// Testcase to check if String() formats envelope that Parse() accepts
+func TestString(t *testing.T) {
+	result := Envelope{Password: samplePassword, Data: sampleData}.String()
+
+	assert.Equal(t, result, sampleEnvelope)
+
+	parsed, err := Parse(result)
+	if err != nil {
+		t.Errorf("failed to parse envelope - %v", err)
+	}
+
+	assert.Equal(t, parsed.String(), result)
+}
@@ -0,0 +1,679 @@
+package general
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// modes of entries in reproducible tgz
+	reproducibleDirMode  = 0755
+	reproducibleFileMode = 0644
+	reproducibleExecMode = 0755
+	reproducibleLinkMode = 0777
+
+	// gzip OS value for unknown, so that archive doesn't depend on host OS
+	gzipUnknownOS = 255
+
+	// maximum number of symlinks followed while resolving a path
+	maxSymlinks = 255
+)
+
+// TgzOptions - settings for tgz generation
+type TgzOptions struct {
+	// Reproducible - sort entries and normalize timestamps, ownership, modes and gzip header, so same content gives same archive
+	Reproducible bool
+	// Exclude - patterns with gitignore syntax to leave out of tgz, applied after patterns of .hpcrignore
+	Exclude []string
+	// Include - patterns with gitignore syntax, if given only matching files and files inside matching folders are added to tgz, with their parent folders
+	Include []string
+	// MaxTotalSize - maximum total uncompressed size of files in bytes (0 means no limit)
+	MaxTotalSize int64
+	// MaxFileCount - maximum number of files and symlinks (0 means no limit)
+	MaxFileCount int
+	// MaxBase64Length - maximum length of base64 of tgz (0 means no limit)
+	MaxBase64Length int
+}
+
+// tgzItem - entry to be written to tgz with the function to read its content
+type tgzItem struct {
+	header *tar.Header
+	open   func() (io.ReadCloser, error)
+}
+
+// tgzFilter - exclude and include patterns applied to entries of tgz
+type tgzFilter struct {
+	exclude *ignoreMatcher
+	include *ignoreMatcher
+}
+
+// GenerateTgzBase64WithOptions - function to generate tgz with given options and return it as base64
+func GenerateTgzBase64WithOptions(folderFilesPath []string, opts TgzOptions) (string, error) {
+	items, err := collectTgzItems(folderFilesPath, opts)
+	if err != nil {
+		return "", err
+	}
+
+	return tgzBase64FromItems(items, opts)
+}
+
+// tgzBase64FromItems - function to check limits, write entries to tgz and return it as base64
+func tgzBase64FromItems(items []tgzItem, opts TgzOptions) (string, error) {
+	err := checkTgzLimits(items, opts)
+	if err != nil {
+		return "", err
+	}
+
+	if opts.Reproducible {
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].header.Name < items[j].header.Name
+		})
+
+		for _, item := range items {
+			normalizeTgzHeader(item.header)
+		}
+	}
+
+	var buf bytes.Buffer
+
+	gw := gzip.NewWriter(&buf)
+	if opts.Reproducible {
+		gw.Header = gzip.Header{OS: gzipUnknownOS}
+	}
+
+	tw := tar.NewWriter(gw)
+
+	for _, item := range items {
+		err := writeTgzItem(tw, item)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return "", err
+	}
+
+	if err := gw.Close(); err != nil {
+		return "", err
+	}
+
+	tgzBase64 := EncodeToBase64(buf.String())
+	if opts.MaxBase64Length > 0 && len(tgzBase64) > opts.MaxBase64Length {
+		return "", fmt.Errorf("base64 of tgz is %d characters which exceeds limit of %d", len(tgzBase64), opts.MaxBase64Length)
+	}
+
+	return tgzBase64, nil
+}
+
+// GenerateTgzManifest - function to generate sorted manifest of files that go into tgz, one "<sha256> <mode> <path>" line per file or symlink
+// Modes are normalized as in reproducible tgz and for symlinks the SHA256 is of the link target
+func GenerateTgzManifest(folderFilesPath []string) (string, error) {
+	return GenerateTgzManifestWithOptions(folderFilesPath, TgzOptions{})
+}
+
+// GenerateTgzManifestWithOptions - function to generate manifest of files that go into tgz generated with given options
+func GenerateTgzManifestWithOptions(folderFilesPath []string, opts TgzOptions) (string, error) {
+	items, err := collectTgzItems(folderFilesPath, opts)
+	if err != nil {
+		return "", err
+	}
+
+	return tgzManifestFromItems(items)
+}
+
+// GenerateTgzBase64AndManifestWithOptions - function to generate tgz as base64 and its manifest from one walk of files and folders
+// Each file is read once, so manifest always describes content of tgz even if files change meanwhile
+func GenerateTgzBase64AndManifestWithOptions(folderFilesPath []string, opts TgzOptions) (string, string, error) {
+	items, err := collectTgzItems(folderFilesPath, opts)
+	if err != nil {
+		return "", "", err
+	}
+
+	return tgzBase64AndManifestFromItems(items, opts)
+}
+
+// tgzBase64AndManifestFromItems - function to read content of entries once and generate tgz as base64 and manifest from it
+func tgzBase64AndManifestFromItems(items []tgzItem, opts TgzOptions) (string, string, error) {
+	// check limits before files are read to memory
+	err := checkTgzLimits(items, opts)
+	if err != nil {
+		return "", "", err
+	}
+
+	for index, item := range items {
+		if item.header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		content, err := readTgzItem(item)
+		if err != nil {
+			return "", "", err
+		}
+
+		items[index].header.Size = int64(len(content))
+		items[index].open = func() (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(content)), nil
+		}
+	}
+
+	manifest, err := tgzManifestFromItems(items)
+	if err != nil {
+		return "", "", err
+	}
+
+	tgzBase64, err := tgzBase64FromItems(items, opts)
+	if err != nil {
+		return "", "", err
+	}
+
+	return tgzBase64, manifest, nil
+}
+
+// tgzManifestFromItems - function to generate manifest of entries, order of items is kept
+func tgzManifestFromItems(items []tgzItem) (string, error) {
+	items = append([]tgzItem{}, items...)
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].header.Name < items[j].header.Name
+	})
+
+	var manifest strings.Builder
+
+	for _, item := range items {
+		var digest string
+
+		switch item.header.Typeflag {
+		case tar.TypeReg:
+			content, err := readTgzItem(item)
+			if err != nil {
+				return "", err
+			}
+			digest = GenerateSha256(content)
+		case tar.TypeSymlink:
+			digest = GenerateSha256(item.header.Linkname)
+		default:
+			continue
+		}
+
+		fmt.Fprintf(&manifest, "%s %04o %s\n", digest, normalizedMode(item.header), item.header.Name)
+	}
+
+	return manifest.String(), nil
+}
+
+// collectTgzItems - function to walk files and folders and create tar headers for them
+// Entry names are relative to the parent folder of each given path, so nested folders keep their structure
+// Files ignored by .hpcrignore of that folder or by exclude patterns, and files not matching include patterns are skipped
+func collectTgzItems(folderFilesPath []string, opts TgzOptions) ([]tgzItem, error) {
+	var items []tgzItem
+
+	filters := map[string]tgzFilter{}
+	pendingDirs := map[string]tgzItem{}
+
+	for _, path := range folderFilesPath {
+		root := filepath.Dir(filepath.Clean(path))
+
+		filter, ok := filters[root]
+		if !ok {
+			ignoreLines, err := readIgnoreFile(root)
+			if err != nil {
+				return nil, err
+			}
+
+			filter = newTgzFilter(ignoreLines, opts)
+			filters[root] = filter
+		}
+
+		err := filepath.Walk(path, func(filePath string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			relPath, err := filepath.Rel(root, filePath)
+			if err != nil {
+				return err
+			}
+			name := filepath.ToSlash(relPath)
+
+			if filter.excluded(name, info.IsDir()) {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			if !filter.included(name, info.IsDir()) {
+				// folders are kept back and added only if a file inside them is included
+				if info.IsDir() {
+					header, err := tar.FileInfoHeader(info, "")
+					if err != nil {
+						return err
+					}
+					header.Name = name + "/"
+					pendingDirs[header.Name] = tgzItem{header: header}
+				}
+				return nil
+			}
+
+			items = appendParentDirs(items, pendingDirs, name)
+
+			var linkTarget string
+			if info.Mode()&os.ModeSymlink != 0 {
+				linkTarget, err = symlinkTarget(root, filePath)
+				if err != nil {
+					return err
+				}
+			}
+
+			header, err := tar.FileInfoHeader(info, linkTarget)
+			if err != nil {
+				return err
+			}
+
+			header.Name = name
+			if info.IsDir() {
+				header.Name += "/"
+			}
+
+			items = append(items, tgzItem{header: header, open: func() (io.ReadCloser, error) {
+				return os.Open(filePath)
+			}})
+
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return items, nil
+}
+
+// newTgzFilter - function to create filter from lines of ignore file and patterns of options
+func newTgzFilter(ignoreLines []string, opts TgzOptions) tgzFilter {
+	filter := tgzFilter{exclude: newIgnoreMatcher(append(ignoreLines, opts.Exclude...))}
+	if len(opts.Include) > 0 {
+		filter.include = newIgnoreMatcher(opts.Include)
+	}
+
+	return filter
+}
+
+// excluded - function to check if entry is excluded by ignore file or exclude patterns
+func (f tgzFilter) excluded(name string, isDir bool) bool {
+	return f.exclude.match(name, isDir)
+}
+
+// included - function to check if entry or one of its parent folders matches include patterns, all entries are included if there are none
+func (f tgzFilter) included(name string, isDir bool) bool {
+	if f.include == nil || f.include.match(name, isDir) {
+		return true
+	}
+
+	for _, dir := range parentDirs(name) {
+		if f.include.match(dir, true) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parentDirs - function to get parent folders of slash separated relative path, outermost first
+func parentDirs(name string) []string {
+	segments := strings.Split(name, "/")
+
+	dirs := make([]string, 0, len(segments)-1)
+	for i := 1; i < len(segments); i++ {
+		dirs = append(dirs, strings.Join(segments[:i], "/"))
+	}
+
+	return dirs
+}
+
+// appendParentDirs - function to add kept back parent folders of entry to items, so folders of included files keep their mode
+func appendParentDirs(items []tgzItem, pendingDirs map[string]tgzItem, name string) []tgzItem {
+	for _, dir := range parentDirs(name) {
+		if item, ok := pendingDirs[dir+"/"]; ok {
+			items = append(items, item)
+			delete(pendingDirs, dir+"/")
+		}
+	}
+
+	return items
+}
+
+// checkTgzLimits - function to check total size and number of files against limits of options
+func checkTgzLimits(items []tgzItem, opts TgzOptions) error {
+	var totalSize int64
+	var fileCount int
+
+	for _, item := range items {
+		if item.header.Typeflag == tar.TypeDir {
+			continue
+		}
+
+		fileCount++
+		totalSize += item.header.Size
+	}
+
+	if opts.MaxFileCount > 0 && fileCount > opts.MaxFileCount {
+		return fmt.Errorf("tgz has %d files which exceeds limit of %d", fileCount, opts.MaxFileCount)
+	}
+
+	if opts.MaxTotalSize > 0 && totalSize > opts.MaxTotalSize {
+		return fmt.Errorf("tgz has %d bytes of files which exceeds limit of %d", totalSize, opts.MaxTotalSize)
+	}
+
+	return nil
+}
+
+// symlinkTarget - function to read target of symlink and check that it stays inside root
+func symlinkTarget(root, linkPath string) (string, error) {
+	target, err := os.Readlink(linkPath)
+	if err != nil {
+		return "", err
+	}
+
+	if filepath.IsAbs(target) {
+		return "", fmt.Errorf("symlink %s points to absolute path %s", linkPath, target)
+	}
+
+	if !isWithinRoot(root, filepath.Join(filepath.Dir(linkPath), target)) {
+		return "", fmt.Errorf("symlink %s points outside of folder - %s", linkPath, target)
+	}
+
+	// target may stay inside as string but escape through other symlinks (eg: c -> b/.. with b -> .)
+	within, err := resolvesWithinRoot(root, linkPath)
+	if err != nil {
+		return "", err
+	}
+
+	if !within {
+		return "", fmt.Errorf("symlink %s points outside of folder through other symlinks - %s", linkPath, target)
+	}
+
+	return filepath.ToSlash(target), nil
+}
+
+// resolvesWithinRoot - function to check if path stays under root after resolving all symlinks of root and path
+func resolvesWithinRoot(root, path string) (bool, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return false, err
+	}
+
+	resolvedRoot, err := resolveSymlinks(absRoot)
+	if err != nil {
+		return false, err
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false, err
+	}
+
+	resolvedPath, err := resolveSymlinks(absPath)
+	if err != nil {
+		return false, err
+	}
+
+	return isWithinRoot(resolvedRoot, resolvedPath), nil
+}
+
+// resolveSymlinks - function to resolve symlinks of absolute path like filepath.EvalSymlinks, missing parts are kept as they are
+func resolveSymlinks(path string) (string, error) {
+	volume := filepath.VolumeName(path)
+	separator := string(filepath.Separator)
+
+	resolved := volume + separator
+	remaining := strings.Split(strings.TrimPrefix(path[len(volume):], separator), separator)
+
+	for links := 0; len(remaining) > 0; {
+		part := remaining[0]
+		remaining = remaining[1:]
+
+		switch part {
+		case "", ".":
+			continue
+		case "..":
+			resolved = filepath.Dir(resolved)
+			continue
+		}
+
+		next := filepath.Join(resolved, part)
+
+		info, err := os.Lstat(next)
+		if os.IsNotExist(err) {
+			resolved = next
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if info.Mode()&os.ModeSymlink == 0 {
+			resolved = next
+			continue
+		}
+
+		links++
+		if links > maxSymlinks {
+			return "", fmt.Errorf("too many symlinks in %s", path)
+		}
+
+		target, err := os.Readlink(next)
+		if err != nil {
+			return "", err
+		}
+
+		if filepath.IsAbs(target) {
+			resolved = volume + separator
+		}
+
+		remaining = append(strings.Split(filepath.FromSlash(target), separator), remaining...)
+	}
+
+	return resolved, nil
+}
+
+// isWithinRoot - function to check if path is root or under root
+func isWithinRoot(root, path string) bool {
+	relPath, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+
+	return relPath != ".." && !strings.HasPrefix(relPath, ".."+string(filepath.Separator))
+}
+
+// normalizeTgzHeader - function to remove timestamps, ownership and permission noise from tar header
+func normalizeTgzHeader(header *tar.Header) {
+	header.ModTime = time.Unix(0, 0)
+	header.AccessTime = time.Time{}
+	header.ChangeTime = time.Time{}
+	header.Uid = 0
+	header.Gid = 0
+	header.Uname = ""
+	header.Gname = ""
+	header.Devmajor = 0
+	header.Devminor = 0
+	header.PAXRecords = nil
+	header.Format = tar.FormatUnknown
+
+	header.Mode = normalizedMode(header)
+}
+
+// normalizedMode - function to get mode of entry that only depends on its type and executable bit, not on umask
+func normalizedMode(header *tar.Header) int64 {
+	switch {
+	case header.Typeflag == tar.TypeSymlink:
+		return reproducibleLinkMode
+	case header.Typeflag == tar.TypeDir:
+		return reproducibleDirMode
+	case header.Mode&0111 != 0:
+		return reproducibleExecMode
+	default:
+		return reproducibleFileMode
+	}
+}
+
+// writeTgzItem - function to write header and content of entry to tar
+func writeTgzItem(tw *tar.Writer, item tgzItem) error {
+	if err := tw.WriteHeader(item.header); err != nil {
+		return err
+	}
+
+	if item.header.Typeflag != tar.TypeReg {
+		return nil
+	}
+
+	file, err := item.open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(tw, file)
+
+	return err
+}
+
+// readTgzItem - function to read content of entry
+func readTgzItem(item tgzItem) (string, error) {
+	file, err := item.open()
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
+
+// ExtractTgzBase64 - function to extract base64 of tgz to destination folder and return extracted paths
+// Entries and symlinks that would be written or point outside of destination, also through other symlinks, are rejected
+// Symlinks are created after folders and files, so no file is written through a symlink of the archive
+func ExtractTgzBase64(tgzBase64, destination string) ([]string, error) {
+	entries, err := ReadTgzBase64(tgzBase64)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := filepath.Abs(destination)
+	if err != nil {
+		return nil, err
+	}
+
+	var extracted []string
+	var links []TgzEntry
+
+	for _, entry := range entries {
+		target := filepath.Join(root, filepath.FromSlash(entry.Name))
+		if filepath.IsAbs(filepath.FromSlash(entry.Name)) || !isWithinRoot(root, target) {
+			return nil, fmt.Errorf("entry %s is outside of destination", entry.Name)
+		}
+
+		switch entry.Typeflag {
+		case tar.TypeDir, tar.TypeReg:
+		case tar.TypeSymlink:
+			linkname := filepath.FromSlash(entry.Linkname)
+			if filepath.IsAbs(linkname) || !isWithinRoot(root, filepath.Join(filepath.Dir(target), linkname)) {
+				return nil, fmt.Errorf("symlink %s points outside of destination - %s", entry.Name, entry.Linkname)
+			}
+			links = append(links, entry)
+			continue
+		default:
+			return nil, fmt.Errorf("unsupported entry type of %s", entry.Name)
+		}
+
+		err = extractTgzEntry(root, target, entry)
+		if err != nil {
+			return nil, err
+		}
+
+		extracted = append(extracted, target)
+	}
+
+	for _, entry := range links {
+		target := filepath.Join(root, filepath.FromSlash(entry.Name))
+
+		err = extractTgzEntry(root, target, entry)
+		if err != nil {
+			return nil, err
+		}
+
+		extracted = append(extracted, target)
+	}
+
+	// a symlink can point outside through symlinks created after it, so all of them are checked at the end
+	for _, entry := range links {
+		target := filepath.Join(root, filepath.FromSlash(entry.Name))
+
+		within, err := resolvesWithinRoot(root, target)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve symlink %s - %v", entry.Name, err)
+		}
+
+		if !within {
+			os.Remove(target)
+			return nil, fmt.Errorf("symlink %s points outside of destination through other symlinks - %s", entry.Name, entry.Linkname)
+		}
+	}
+
+	return extracted, nil
+}
+
+// extractTgzEntry - function to write folder, file or symlink of entry after checking that its path doesn't resolve outside of root
+func extractTgzEntry(root, target string, entry TgzEntry) error {
+	within, err := resolvesWithinRoot(root, filepath.Dir(target))
+	if err != nil {
+		return fmt.Errorf("failed to resolve path of %s - %v", entry.Name, err)
+	}
+
+	if !within {
+		return fmt.Errorf("entry %s is written outside of destination through symlink", entry.Name)
+	}
+
+	err = os.MkdirAll(filepath.Dir(target), reproducibleDirMode)
+	if err != nil {
+		return err
+	}
+
+	switch entry.Typeflag {
+	case tar.TypeDir:
+		err = os.MkdirAll(target, os.FileMode(entry.Mode).Perm())
+	case tar.TypeReg:
+		// existing symlink at target would be followed by WriteFile
+		info, statErr := os.Lstat(target)
+		if statErr == nil && info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("entry %s is written through symlink", entry.Name)
+		}
+
+		err = os.WriteFile(target, []byte(entry.Content), os.FileMode(entry.Mode).Perm())
+		if err == nil {
+			err = os.Chmod(target, os.FileMode(entry.Mode).Perm())
+		}
+	case tar.TypeSymlink:
+		err = os.Symlink(filepath.FromSlash(entry.Linkname), target)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to extract %s - %v", entry.Name, err)
+	}
+
+	return nil
+}
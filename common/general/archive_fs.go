@@ -0,0 +1,227 @@
+package general
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+	"strings"
+	"time"
+)
+
+// GenerateTgzBase64FromFS - function to generate tgz of all files in fs.FS (eg: embed.FS) and return it as base64
+func GenerateTgzBase64FromFS(fsys fs.FS, opts TgzOptions) (string, error) {
+	items, err := collectFsTgzItems(fsys, opts)
+	if err != nil {
+		return "", err
+	}
+
+	return tgzBase64FromItems(items, opts)
+}
+
+// GenerateTgzManifestFromFS - function to generate manifest of files in fs.FS that go into tgz
+func GenerateTgzManifestFromFS(fsys fs.FS, opts TgzOptions) (string, error) {
+	items, err := collectFsTgzItems(fsys, opts)
+	if err != nil {
+		return "", err
+	}
+
+	return tgzManifestFromItems(items)
+}
+
+// GenerateTgzBase64AndManifestFromFS - function to generate tgz as base64 and its manifest from one walk of fs.FS
+func GenerateTgzBase64AndManifestFromFS(fsys fs.FS, opts TgzOptions) (string, string, error) {
+	items, err := collectFsTgzItems(fsys, opts)
+	if err != nil {
+		return "", "", err
+	}
+
+	return tgzBase64AndManifestFromItems(items, opts)
+}
+
+// GenerateTgzBase64FromMap - function to generate tgz of in-memory files (slash separated path to content) and return it as base64
+func GenerateTgzBase64FromMap(files map[string]string, opts TgzOptions) (string, error) {
+	items, err := collectMapTgzItems(files, opts)
+	if err != nil {
+		return "", err
+	}
+
+	return tgzBase64FromItems(items, opts)
+}
+
+// GenerateTgzBase64AndManifestFromMap - function to generate tgz as base64 and its manifest from one collection of in-memory files
+// Entries get the same modification time, so manifest and tgz describe the same entries
+func GenerateTgzBase64AndManifestFromMap(files map[string]string, opts TgzOptions) (string, string, error) {
+	items, err := collectMapTgzItems(files, opts)
+	if err != nil {
+		return "", "", err
+	}
+
+	return tgzBase64AndManifestFromItems(items, opts)
+}
+
+// GenerateTgzManifestFromMap - function to generate manifest of in-memory files that go into tgz
+func GenerateTgzManifestFromMap(files map[string]string, opts TgzOptions) (string, error) {
+	items, err := collectMapTgzItems(files, opts)
+	if err != nil {
+		return "", err
+	}
+
+	return tgzManifestFromItems(items)
+}
+
+// collectFsTgzItems - function to walk fs.FS and create tar headers for its files and folders
+func collectFsTgzItems(fsys fs.FS, opts TgzOptions) ([]tgzItem, error) {
+	if fsys == nil {
+		return nil, fmt.Errorf("file system is missing")
+	}
+
+	var ignoreLines []string
+
+	ignoreFile, err := fs.ReadFile(fsys, HpcrIgnoreFile)
+	if err == nil {
+		ignoreLines = strings.Split(string(ignoreFile), "\n")
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	filter := newTgzFilter(ignoreLines, opts)
+
+	var items []tgzItem
+
+	pendingDirs := map[string]tgzItem{}
+
+	err = fs.WalkDir(fsys, ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path == "." {
+			return nil
+		}
+
+		if filter.excluded(path, entry.IsDir()) {
+			if entry.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		if !filter.included(path, entry.IsDir()) {
+			// folders are kept back and added only if a file inside them is included, like folders on disk
+			if entry.IsDir() {
+				header, err := fsTgzHeader(entry, path)
+				if err != nil {
+					return err
+				}
+				pendingDirs[header.Name] = tgzItem{header: header}
+			}
+			return nil
+		}
+
+		items = appendParentDirs(items, pendingDirs, path)
+
+		if entry.Type()&fs.ModeSymlink != 0 {
+			return fmt.Errorf("symlink %s is not supported in fs.FS", path)
+		}
+
+		header, err := fsTgzHeader(entry, path)
+		if err != nil {
+			return err
+		}
+
+		items = append(items, tgzItem{header: header, open: func() (io.ReadCloser, error) {
+			return fsys.Open(path)
+		}})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// fsTgzHeader - function to create tar header of fs.FS entry, folder names end with slash
+func fsTgzHeader(entry fs.DirEntry, path string) (*tar.Header, error) {
+	info, err := entry.Info()
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return nil, err
+	}
+
+	header.Name = path
+	if entry.IsDir() {
+		header.Name += "/"
+	}
+
+	return header, nil
+}
+
+// collectMapTgzItems - function to create tar headers for in-memory files, parent folders are not added as entries
+func collectMapTgzItems(files map[string]string, opts TgzOptions) ([]tgzItem, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files are given")
+	}
+
+	var ignoreLines []string
+	if ignoreFile, ok := files[HpcrIgnoreFile]; ok {
+		ignoreLines = strings.Split(ignoreFile, "\n")
+	}
+
+	filter := newTgzFilter(ignoreLines, opts)
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		if !fs.ValidPath(name) || name == "." {
+			return nil, fmt.Errorf("invalid file path - %s", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var items []tgzItem
+	modTime := time.Now()
+
+	for _, name := range names {
+		if excludedByParent(filter, name) || filter.excluded(name, false) || !filter.included(name, false) {
+			continue
+		}
+
+		content := files[name]
+
+		items = append(items, tgzItem{
+			header: &tar.Header{
+				Typeflag: tar.TypeReg,
+				Name:     name,
+				Mode:     reproducibleFileMode,
+				Size:     int64(len(content)),
+				ModTime:  modTime,
+			},
+			open: func() (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader(content)), nil
+			},
+		})
+	}
+
+	return items, nil
+}
+
+// excludedByParent - function to check if any parent folder of path is excluded, as walking would skip it
+func excludedByParent(filter tgzFilter, name string) bool {
+	segments := strings.Split(name, "/")
+	for i := 1; i < len(segments); i++ {
+		if filter.excluded(strings.Join(segments[:i], "/"), true) {
+			return true
+		}
+	}
+
+	return false
+}
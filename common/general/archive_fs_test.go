@@ -0,0 +1,174 @@
+package general
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Testcase to check if GenerateTgzBase64FromFS() generates tgz with nested files of fs.FS
+func TestGenerateTgzBase64FromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"docker-compose.yaml": {Data: []byte("services: {}")},
+		"config/nginx.conf":   {Data: []byte("server {}")},
+		"notes.md":            {Data: []byte("notes")},
+		HpcrIgnoreFile:        {Data: []byte("*.md\n")},
+	}
+
+	result, err := GenerateTgzBase64FromFS(fsys, TgzOptions{})
+	if err != nil {
+		t.Errorf("failed to generate TGZ base64 - %v", err)
+	}
+
+	entries, err := ReadTgzBase64(result)
+	if err != nil {
+		t.Errorf("failed to read TGZ base64 - %v", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name)
+	}
+
+	assert.Contains(t, names, "config/nginx.conf")
+	assert.Contains(t, names, "docker-compose.yaml")
+	assert.NotContains(t, names, "notes.md")
+}
+
+// Testcase to check if GenerateTgzBase64FromFS() adds parent folders of included files once each, like folder on disk
+func TestGenerateTgzBase64FromFSIncludeParentFolders(t *testing.T) {
+	folder := t.TempDir()
+
+	for _, name := range []string{"docker-compose.yaml", "config/app/nginx.conf", "config/app/proxy.conf", "config/app/notes.md"} {
+		err := os.MkdirAll(filepath.Dir(filepath.Join(folder, name)), 0755)
+		if err != nil {
+			t.Fatalf("failed to create folder - %v", err)
+		}
+
+		err = os.WriteFile(filepath.Join(folder, name), []byte(name), 0644)
+		if err != nil {
+			t.Fatalf("failed to write file - %v", err)
+		}
+	}
+
+	filesFoldersList, err := ListFoldersAndFiles(folder)
+	if err != nil {
+		t.Errorf("failed to list files and folders - %v", err)
+	}
+
+	opts := TgzOptions{Include: []string{"*.conf"}}
+	expected := []string{"config/", "config/app/", "config/app/nginx.conf", "config/app/proxy.conf"}
+
+	tgzBase64, err := GenerateTgzBase64WithOptions(filesFoldersList, opts)
+	if err != nil {
+		t.Errorf("failed to generate TGZ base64 - %v", err)
+	}
+
+	fsTgzBase64, err := GenerateTgzBase64FromFS(os.DirFS(folder), opts)
+	if err != nil {
+		t.Errorf("failed to generate TGZ base64 - %v", err)
+	}
+
+	for _, result := range []string{tgzBase64, fsTgzBase64} {
+		entries, err := ReadTgzBase64(result)
+		if err != nil {
+			t.Errorf("failed to read TGZ base64 - %v", err)
+		}
+
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name)
+		}
+
+		assert.Equal(t, names, expected)
+	}
+}
+
+// Testcase to check if GenerateTgzManifestFromFS() gives same manifest as folder on disk
+func TestGenerateTgzManifestFromFS(t *testing.T) {
+	filesFoldersList, err := ListFoldersAndFiles(sampleComposeFolder)
+	if err != nil {
+		t.Errorf("failed to list files and folders - %v", err)
+	}
+
+	expected, err := GenerateTgzManifest(filesFoldersList)
+	if err != nil {
+		t.Errorf("failed to generate manifest - %v", err)
+	}
+
+	result, err := GenerateTgzManifestFromFS(os.DirFS(sampleComposeFolder), TgzOptions{})
+	if err != nil {
+		t.Errorf("failed to generate manifest - %v", err)
+	}
+
+	assert.Equal(t, result, expected)
+}
+
+// Testcase to check if GenerateTgzBase64FromMap() generates tgz of in-memory files
+func TestGenerateTgzBase64FromMap(t *testing.T) {
+	files := map[string]string{
+		"docker-compose.yaml": "services: {}",
+		"config/nginx.conf":   "server {}",
+		".git/config":         "git",
+	}
+
+	result, err := GenerateTgzBase64FromMap(files, TgzOptions{Exclude: []string{".git/"}})
+	if err != nil {
+		t.Errorf("failed to generate TGZ base64 - %v", err)
+	}
+
+	entries, err := ReadTgzBase64(result)
+	if err != nil {
+		t.Errorf("failed to read TGZ base64 - %v", err)
+	}
+
+	assert.Len(t, entries, 2)
+	assert.Equal(t, entries[0].Name, "config/nginx.conf")
+	assert.Equal(t, entries[0].Content, "server {}")
+
+	_, err = GenerateTgzBase64FromMap(map[string]string{"../evil": "evil"}, TgzOptions{})
+	assert.Error(t, err)
+}
+
+// Testcase to check if GenerateTgzManifestFromMap() gives same manifest as folder on disk with same content
+func TestGenerateTgzManifestFromMap(t *testing.T) {
+	raw, err := os.ReadFile(sampleComposeFolder + "/docker-compose.yaml")
+	if err != nil {
+		t.Errorf("failed to read compose file - %v", err)
+	}
+
+	result, err := GenerateTgzManifestFromMap(map[string]string{"docker-compose.yaml": string(raw)}, TgzOptions{})
+	if err != nil {
+		t.Errorf("failed to generate manifest - %v", err)
+	}
+
+	assert.Equal(t, result, GenerateSha256(string(raw))+" 0644 docker-compose.yaml\n")
+}
+
+// Testcase to check if GenerateTgzBase64AndManifestFromMap() returns manifest of exactly the files in tgz
+func TestGenerateTgzBase64AndManifestFromMap(t *testing.T) {
+	files := map[string]string{
+		"docker-compose.yaml": "services: {}",
+		"config/nginx.conf":   "server {}",
+		".git/config":         "git",
+	}
+
+	tgzBase64, manifest, err := GenerateTgzBase64AndManifestFromMap(files, TgzOptions{Exclude: []string{".git/"}})
+	if err != nil {
+		t.Errorf("failed to generate TGZ base64 and manifest - %v", err)
+	}
+
+	entries, err := ReadTgzBase64(tgzBase64)
+	if err != nil {
+		t.Errorf("failed to read TGZ base64 - %v", err)
+	}
+
+	assert.Len(t, entries, 2)
+	assert.Equal(t, manifest, GenerateSha256("server {}")+" 0644 config/nginx.conf\n"+GenerateSha256("services: {}")+" 0644 docker-compose.yaml\n")
+
+	_, _, err = GenerateTgzBase64AndManifestFromMap(map[string]string{}, TgzOptions{})
+	assert.Error(t, err)
+}
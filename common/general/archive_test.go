@@ -0,0 +1,453 @@
+package general
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// copyComposeFolder - function to copy sample compose folder to temporary folder with given modification time and mode
+func copyComposeFolder(t *testing.T, modTime time.Time, mode os.FileMode) string {
+	folder := t.TempDir()
+
+	content, err := os.ReadFile(filepath.Join(sampleComposeFolder, "docker-compose.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read compose file - %v", err)
+	}
+
+	filePath := filepath.Join(folder, "docker-compose.yaml")
+	err = os.WriteFile(filePath, content, mode)
+	if err != nil {
+		t.Fatalf("failed to write compose file - %v", err)
+	}
+
+	err = os.Chmod(filePath, mode)
+	if err != nil {
+		t.Fatalf("failed to change mode of compose file - %v", err)
+	}
+
+	err = os.Chtimes(filePath, modTime, modTime)
+	if err != nil {
+		t.Fatalf("failed to change time of compose file - %v", err)
+	}
+
+	return folder
+}
+
+// Testcase to check if GenerateTgzBase64WithOptions() generates same archive for same content in reproducible mode
+func TestGenerateTgzBase64WithOptions(t *testing.T) {
+	first := copyComposeFolder(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), 0600)
+	second := copyComposeFolder(t, time.Date(2024, 6, 1, 12, 30, 0, 0, time.UTC), 0664)
+
+	firstFiles, err := ListFoldersAndFiles(first)
+	if err != nil {
+		t.Errorf("failed to list files and folders - %v", err)
+	}
+
+	secondFiles, err := ListFoldersAndFiles(second)
+	if err != nil {
+		t.Errorf("failed to list files and folders - %v", err)
+	}
+
+	firstResult, err := GenerateTgzBase64WithOptions(firstFiles, TgzOptions{Reproducible: true})
+	if err != nil {
+		t.Errorf("failed to generate TGZ base64 - %v", err)
+	}
+
+	secondResult, err := GenerateTgzBase64WithOptions(secondFiles, TgzOptions{Reproducible: true})
+	if err != nil {
+		t.Errorf("failed to generate TGZ base64 - %v", err)
+	}
+
+	assert.Equal(t, firstResult, secondResult)
+
+	entries, err := ReadTgzBase64(firstResult)
+	if err != nil {
+		t.Errorf("failed to read TGZ base64 - %v", err)
+	}
+
+	assert.Equal(t, entries[0].Mode, int64(reproducibleFileMode))
+
+	firstResult, err = GenerateTgzBase64WithOptions(firstFiles, TgzOptions{})
+	if err != nil {
+		t.Errorf("failed to generate TGZ base64 - %v", err)
+	}
+
+	secondResult, err = GenerateTgzBase64WithOptions(secondFiles, TgzOptions{})
+	if err != nil {
+		t.Errorf("failed to generate TGZ base64 - %v", err)
+	}
+
+	assert.NotEqual(t, firstResult, secondResult)
+}
+
+// createNestedFolder - function to create compose folder with nested folder, executable script and symlink
+func createNestedFolder(t *testing.T) string {
+	folder := t.TempDir()
+
+	files := map[string]os.FileMode{
+		"docker-compose.yaml": 0644,
+		"config/nginx.conf":   0644,
+		"bin/start.sh":        0755,
+	}
+
+	for name, mode := range files {
+		filePath := filepath.Join(folder, name)
+
+		err := os.MkdirAll(filepath.Dir(filePath), 0755)
+		if err != nil {
+			t.Fatalf("failed to create folder - %v", err)
+		}
+
+		err = os.WriteFile(filePath, []byte(name), mode)
+		if err != nil {
+			t.Fatalf("failed to write file - %v", err)
+		}
+	}
+
+	err := os.Symlink("config/nginx.conf", filepath.Join(folder, "nginx.conf"))
+	if err != nil {
+		t.Fatalf("failed to create symlink - %v", err)
+	}
+
+	return folder
+}
+
+// Testcase to check if ExtractTgzBase64() restores nested folders, executable bits and symlinks
+func TestExtractTgzBase64(t *testing.T) {
+	folder := createNestedFolder(t)
+
+	filesFoldersList, err := ListFoldersAndFiles(folder)
+	if err != nil {
+		t.Errorf("failed to list files and folders - %v", err)
+	}
+
+	tgzBase64, err := GenerateTgzBase64(filesFoldersList)
+	if err != nil {
+		t.Errorf("failed to generate TGZ base64 - %v", err)
+	}
+
+	destination := t.TempDir()
+
+	_, err = ExtractTgzBase64(tgzBase64, destination)
+	if err != nil {
+		t.Errorf("failed to extract TGZ base64 - %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(destination, "config", "nginx.conf"))
+	if err != nil {
+		t.Errorf("failed to read extracted file - %v", err)
+	}
+
+	assert.Equal(t, string(content), "config/nginx.conf")
+
+	info, err := os.Stat(filepath.Join(destination, "bin", "start.sh"))
+	if err != nil {
+		t.Errorf("failed to stat extracted file - %v", err)
+	}
+
+	assert.NotZero(t, info.Mode()&0100)
+
+	linkTarget, err := os.Readlink(filepath.Join(destination, "nginx.conf"))
+	if err != nil {
+		t.Errorf("failed to read extracted symlink - %v", err)
+	}
+
+	assert.Equal(t, linkTarget, "config/nginx.conf")
+}
+
+// Testcase to check if GenerateTgzBase64() rejects symlink pointing outside of folder
+func TestGenerateTgzBase64SymlinkOutside(t *testing.T) {
+	folder := createNestedFolder(t)
+
+	err := os.Symlink("../../etc/passwd", filepath.Join(folder, "config", "passwd"))
+	if err != nil {
+		t.Fatalf("failed to create symlink - %v", err)
+	}
+
+	filesFoldersList, err := ListFoldersAndFiles(folder)
+	if err != nil {
+		t.Errorf("failed to list files and folders - %v", err)
+	}
+
+	_, err = GenerateTgzBase64(filesFoldersList)
+	assert.ErrorContains(t, err, "outside of folder")
+}
+
+// Testcase to check if ExtractTgzBase64() rejects entries outside of destination
+func TestExtractTgzBase64Outside(t *testing.T) {
+	var buf bytes.Buffer
+
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+
+	err := tw.WriteHeader(&tar.Header{Name: "../evil", Typeflag: tar.TypeReg, Mode: 0644, Size: 4})
+	if err != nil {
+		t.Fatalf("failed to write tar header - %v", err)
+	}
+
+	_, err = tw.Write([]byte("evil"))
+	if err != nil {
+		t.Fatalf("failed to write tar content - %v", err)
+	}
+
+	tw.Close()
+	gw.Close()
+
+	_, err = ExtractTgzBase64(EncodeToBase64(buf.String()), t.TempDir())
+	assert.ErrorContains(t, err, "outside of destination")
+}
+
+// Testcase to check if GenerateTgzManifest() lists files sorted with normalized mode and SHA256
+func TestGenerateTgzManifest(t *testing.T) {
+	folder := createNestedFolder(t)
+
+	filesFoldersList, err := ListFoldersAndFiles(folder)
+	if err != nil {
+		t.Errorf("failed to list files and folders - %v", err)
+	}
+
+	result, err := GenerateTgzManifest(filesFoldersList)
+	if err != nil {
+		t.Errorf("failed to generate manifest - %v", err)
+	}
+
+	expected := GenerateSha256("bin/start.sh") + " 0755 bin/start.sh\n" +
+		GenerateSha256("config/nginx.conf") + " 0644 config/nginx.conf\n" +
+		GenerateSha256("docker-compose.yaml") + " 0644 docker-compose.yaml\n" +
+		GenerateSha256("config/nginx.conf") + " 0777 nginx.conf\n"
+
+	assert.Equal(t, result, expected)
+}
+
+// Testcase to check if GenerateTgzBase64WithOptions() leaves out files from .hpcrignore and exclude patterns and honours include patterns
+func TestGenerateTgzBase64WithOptionsIgnore(t *testing.T) {
+	folder := createNestedFolder(t)
+
+	for name, content := range map[string]string{
+		".git/config":         "git",
+		".env":                "SECRET=1",
+		HpcrIgnoreFile:        ".git/\n.env\n",
+		"config/nginx.conf~":  "backup",
+		"config/default.conf": "default",
+	} {
+		err := os.MkdirAll(filepath.Dir(filepath.Join(folder, name)), 0755)
+		if err != nil {
+			t.Fatalf("failed to create folder - %v", err)
+		}
+
+		err = os.WriteFile(filepath.Join(folder, name), []byte(content), 0644)
+		if err != nil {
+			t.Fatalf("failed to write file - %v", err)
+		}
+	}
+
+	filesFoldersList, err := ListFoldersAndFiles(folder)
+	if err != nil {
+		t.Errorf("failed to list files and folders - %v", err)
+	}
+
+	result, err := GenerateTgzManifestWithOptions(filesFoldersList, TgzOptions{Exclude: []string{"*~"}})
+	if err != nil {
+		t.Errorf("failed to generate manifest - %v", err)
+	}
+
+	assert.Contains(t, result, " config/default.conf\n")
+	assert.NotContains(t, result, ".git/config")
+	assert.NotContains(t, result, " .env\n")
+	assert.NotContains(t, result, "nginx.conf~")
+
+	result, err = GenerateTgzManifestWithOptions(filesFoldersList, TgzOptions{Include: []string{"config/*.conf", "docker-compose.yaml"}})
+	if err != nil {
+		t.Errorf("failed to generate manifest - %v", err)
+	}
+
+	assert.Equal(t, strings.Count(result, "\n"), 3)
+	assert.NotContains(t, result, "start.sh")
+}
+
+// Testcase to check if GenerateTgzBase64WithOptions() returns error when limits are exceeded
+func TestGenerateTgzBase64WithOptionsLimits(t *testing.T) {
+	folder := createNestedFolder(t)
+
+	filesFoldersList, err := ListFoldersAndFiles(folder)
+	if err != nil {
+		t.Errorf("failed to list files and folders - %v", err)
+	}
+
+	_, err = GenerateTgzBase64WithOptions(filesFoldersList, TgzOptions{MaxFileCount: 3})
+	assert.ErrorContains(t, err, "exceeds limit of 3")
+
+	_, err = GenerateTgzBase64WithOptions(filesFoldersList, TgzOptions{MaxTotalSize: 10})
+	assert.ErrorContains(t, err, "exceeds limit of 10")
+
+	_, err = GenerateTgzBase64WithOptions(filesFoldersList, TgzOptions{MaxBase64Length: 100})
+	assert.ErrorContains(t, err, "exceeds limit of 100")
+
+	_, err = GenerateTgzBase64WithOptions(filesFoldersList, TgzOptions{MaxFileCount: 4, MaxTotalSize: 1024, MaxBase64Length: 4096})
+	assert.NoError(t, err)
+}
+
+// Testcase to check if ExtractTgzBase64() rejects entries written or pointing outside of destination through chained symlinks
+func TestExtractTgzBase64ChainedSymlinks(t *testing.T) {
+	var buf bytes.Buffer
+
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+
+	for _, header := range []*tar.Header{
+		{Name: "a/", Typeflag: tar.TypeDir, Mode: 0755},
+		{Name: "a/b", Typeflag: tar.TypeSymlink, Linkname: "..", Mode: 0777},
+		{Name: "s", Typeflag: tar.TypeSymlink, Linkname: "a/b", Mode: 0777},
+		{Name: "t", Typeflag: tar.TypeSymlink, Linkname: "s/..", Mode: 0777},
+		{Name: "t/pwned", Typeflag: tar.TypeReg, Mode: 0644, Size: 4},
+	} {
+		err := tw.WriteHeader(header)
+		if err != nil {
+			t.Fatalf("failed to write tar header - %v", err)
+		}
+
+		if header.Typeflag == tar.TypeReg {
+			_, err = tw.Write([]byte("evil"))
+			if err != nil {
+				t.Fatalf("failed to write tar content - %v", err)
+			}
+		}
+	}
+
+	tw.Close()
+	gw.Close()
+
+	parent := t.TempDir()
+	destination := filepath.Join(parent, "destination")
+
+	_, err := ExtractTgzBase64(EncodeToBase64(buf.String()), destination)
+	assert.Error(t, err)
+
+	_, err = os.Lstat(filepath.Join(parent, "pwned"))
+	assert.True(t, os.IsNotExist(err))
+
+	// without the file, the last symlink itself points outside of destination
+	buf.Reset()
+	gw = gzip.NewWriter(&buf)
+	tw = tar.NewWriter(gw)
+
+	for _, header := range []*tar.Header{
+		{Name: "a/", Typeflag: tar.TypeDir, Mode: 0755},
+		{Name: "a/b", Typeflag: tar.TypeSymlink, Linkname: "..", Mode: 0777},
+		{Name: "s", Typeflag: tar.TypeSymlink, Linkname: "a/b", Mode: 0777},
+		{Name: "t", Typeflag: tar.TypeSymlink, Linkname: "s/..", Mode: 0777},
+	} {
+		err := tw.WriteHeader(header)
+		if err != nil {
+			t.Fatalf("failed to write tar header - %v", err)
+		}
+	}
+
+	tw.Close()
+	gw.Close()
+
+	_, err = ExtractTgzBase64(EncodeToBase64(buf.String()), filepath.Join(parent, "links"))
+	assert.ErrorContains(t, err, "symlink t points outside of destination through other symlinks")
+}
+
+// Testcase to check if GenerateTgzBase64() rejects symlink pointing outside of folder through other symlinks
+func TestGenerateTgzBase64ChainedSymlinks(t *testing.T) {
+	folder := t.TempDir()
+
+	err := os.Symlink(".", filepath.Join(folder, "b"))
+	if err != nil {
+		t.Fatalf("failed to create symlink - %v", err)
+	}
+
+	err = os.Symlink("b/..", filepath.Join(folder, "c"))
+	if err != nil {
+		t.Fatalf("failed to create symlink - %v", err)
+	}
+
+	filesFoldersList, err := ListFoldersAndFiles(folder)
+	if err != nil {
+		t.Errorf("failed to list files and folders - %v", err)
+	}
+
+	_, err = GenerateTgzBase64(filesFoldersList)
+	assert.ErrorContains(t, err, "outside of folder through other symlinks")
+}
+
+// Testcase to check if GenerateTgzBase64WithOptions() adds everything inside folders matching include patterns and parent folders of included files
+func TestGenerateTgzBase64WithOptionsIncludeFolder(t *testing.T) {
+	folder := createNestedFolder(t)
+
+	filesFoldersList, err := ListFoldersAndFiles(folder)
+	if err != nil {
+		t.Errorf("failed to list files and folders - %v", err)
+	}
+
+	for include, expected := range map[string][]string{
+		"config/":       {"config/", "config/nginx.conf"},
+		"config":        {"config/", "config/nginx.conf"},
+		"/bin":          {"bin/", "bin/start.sh"},
+		"*.conf":        {"config/", "config/nginx.conf", "nginx.conf"},
+		"config/*.conf": {"config/", "config/nginx.conf"},
+	} {
+		tgzBase64, err := GenerateTgzBase64WithOptions(filesFoldersList, TgzOptions{Include: []string{include}, Reproducible: true})
+		if err != nil {
+			t.Errorf("failed to generate TGZ base64 - %v", err)
+		}
+
+		entries, err := ReadTgzBase64(tgzBase64)
+		if err != nil {
+			t.Errorf("failed to read TGZ base64 - %v", err)
+		}
+
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name)
+		}
+
+		assert.Equal(t, names, expected, include)
+	}
+}
+
+// Testcase to check if GenerateTgzBase64AndManifestWithOptions() returns manifest of exactly the files in tgz
+func TestGenerateTgzBase64AndManifestWithOptions(t *testing.T) {
+	folder := createNestedFolder(t)
+
+	filesFoldersList, err := ListFoldersAndFiles(folder)
+	if err != nil {
+		t.Errorf("failed to list files and folders - %v", err)
+	}
+
+	tgzBase64, manifest, err := GenerateTgzBase64AndManifestWithOptions(filesFoldersList, TgzOptions{})
+	if err != nil {
+		t.Errorf("failed to generate TGZ base64 and manifest - %v", err)
+	}
+
+	expected, err := GenerateTgzManifest(filesFoldersList)
+	if err != nil {
+		t.Errorf("failed to generate manifest - %v", err)
+	}
+
+	assert.Equal(t, manifest, expected)
+
+	entries, err := ReadTgzBase64(tgzBase64)
+	if err != nil {
+		t.Errorf("failed to read TGZ base64 - %v", err)
+	}
+
+	for _, entry := range entries {
+		if entry.Typeflag == tar.TypeReg {
+			assert.Contains(t, manifest, GenerateSha256(entry.Content)+" ")
+		}
+	}
+
+	_, _, err = GenerateTgzBase64AndManifestWithOptions(filesFoldersList, TgzOptions{MaxFileCount: 1})
+	assert.ErrorContains(t, err, "exceeds limit of 1")
+}
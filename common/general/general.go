@@ -4,10 +4,13 @@ import (
 	"archive/tar"
 	"bytes"
 	"compress/gzip"
+	"crypto/rsa"
 	"crypto/sha256"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"net/http"
@@ -23,6 +26,7 @@ import (
 
 	sch "github.com/Sashwat-K/hpcr-contract-schema"
 	cert "github.com/Sashwat-K/hpcr-encryption-certificate"
+	"github.com/Sashwat-K/lib-hpcr/common/envelope"
 )
 
 // CheckIfEmpty - function to check if given arguments are not empty
@@ -88,7 +92,11 @@ func ReadDataFromFile(filePath string) (string, error) {
 
 // CreateTempFile - function to create temp file
 func CreateTempFile(data string) (string, error) {
-	trimmedData := strings.TrimSpace(data)
+	return CreateBinaryTempFile(strings.TrimSpace(data))
+}
+
+// CreateBinaryTempFile - function to create temp file with data written as is (eg: cipher text)
+func CreateBinaryTempFile(data string) (string, error) {
 	tmpFile, err := os.CreateTemp("", "hpvs-")
 	if err != nil {
 		return "", err
@@ -96,7 +104,7 @@ func CreateTempFile(data string) (string, error) {
 	defer tmpFile.Close()
 
 	// Write the data to the temp file.
-	_, err = tmpFile.WriteString(trimmedData)
+	_, err = tmpFile.WriteString(data)
 	if err != nil {
 		return "", err
 	}
@@ -223,10 +231,23 @@ func CertificateDownloader(url string) (string, error) {
 }
 
 // GetEncryptPassWorkload - function to get encrypted password and encrypted workload from data
+//
+// Deprecated: panics if data has less than three parts, use GetEncryptPassWorkloadWithError instead
 func GetEncryptPassWorkload(encryptedData string) (string, string) {
 	return strings.Split(encryptedData, ".")[1], strings.Split(encryptedData, ".")[2]
 }
 
+// GetEncryptPassWorkloadWithError - function to get encrypted password and encrypted workload from data
+// Error is returned if data is not a valid hyper-protect-basic envelope
+func GetEncryptPassWorkloadWithError(encryptedData string) (string, string, error) {
+	encryptedEnvelope, err := envelope.Parse(encryptedData)
+	if err != nil {
+		return "", "", err
+	}
+
+	return encryptedEnvelope.Password, encryptedEnvelope.Data, nil
+}
+
 // CheckUrlExists - function to check if URL exists or not
 func CheckUrlExists(url string) (bool, error) {
 	response, err := http.Head(url)
@@ -285,59 +306,58 @@ func FetchEncryptionCertificate(encryptionCertificate string) string {
 
 // GenerateTgzBase64 - function to generate tgz and return it as base64
 func GenerateTgzBase64(folderFilesPath []string) (string, error) {
-	var buf bytes.Buffer
-
-	gw := gzip.NewWriter(&buf)
-	defer gw.Close()
-
-	tw := tar.NewWriter(gw)
-	defer tw.Close()
-
-	for _, path := range folderFilesPath {
-		err := filepath.Walk(path, func(filePath string, info os.FileInfo, err error) error {
-			if err != nil {
-				return err
-			}
-
-			relPath, err := filepath.Rel(path, filePath)
-			if err != nil {
-				return err
-			}
+	return GenerateTgzBase64WithOptions(folderFilesPath, TgzOptions{})
+}
 
-			header, err := tar.FileInfoHeader(info, relPath)
-			if err != nil {
-				return err
-			}
+// TgzEntry - file, folder or link read from tgz
+type TgzEntry struct {
+	Name     string
+	Typeflag byte
+	Mode     int64
+	Linkname string
+	Content  string
+}
 
-			if err := tw.WriteHeader(header); err != nil {
-				return err
-			}
+// ReadTgzBase64 - function to read entries from base64 of tgz
+func ReadTgzBase64(tgzBase64 string) ([]TgzEntry, error) {
+	tgz, err := DecodeBase64String(tgzBase64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode base64 - %v", err)
+	}
 
-			if !info.IsDir() {
-				file, err := os.Open(filePath)
-				if err != nil {
-					return err
-				}
-				defer file.Close()
+	gr, err := gzip.NewReader(strings.NewReader(tgz))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gzip - %v", err)
+	}
+	defer gr.Close()
 
-				if _, err := io.Copy(tw, file); err != nil {
-					return err
-				}
-			}
+	var entries []TgzEntry
 
-			return nil
-		})
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tar - %v", err)
+		}
 
+		content, err := io.ReadAll(tr)
 		if err != nil {
-			return "", err
+			return nil, fmt.Errorf("failed to read %s from tar - %v", header.Name, err)
 		}
-	}
 
-	if err := gw.Flush(); err != nil {
-		return "", err
+		entries = append(entries, TgzEntry{
+			Name:     header.Name,
+			Typeflag: header.Typeflag,
+			Mode:     header.Mode,
+			Linkname: header.Linkname,
+			Content:  string(content),
+		})
 	}
 
-	return EncodeToBase64(buf.String()), nil
+	return entries, nil
 }
 
 // VerifyContractWithSchema - function to verify if contract matches schema
@@ -373,3 +393,147 @@ func VerifyContractWithSchema(contract string) error {
 		return fmt.Errorf("validation failed - %s", consolidatedErrors.String())
 	}
 }
+
+// ParseRsaPrivateKey - function to parse RSA private key from PEM (PKCS#1 or PKCS#8)
+func ParseRsaPrivateKey(privateKey string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(strings.TrimSpace(privateKey)))
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode PEM private key")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		rsaKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("private key is not an RSA key")
+		}
+
+		return rsaKey, nil
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type - %s", block.Type)
+	}
+}
+
+// ParseRsaPublicKey - function to parse RSA public key from PEM public key or certificate
+func ParseRsaPublicKey(publicKeyOrCert string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(strings.TrimSpace(publicKeyOrCert)))
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode PEM public key or certificate")
+	}
+
+	var key interface{}
+	var err error
+
+	switch block.Type {
+	case "CERTIFICATE":
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key = certificate.PublicKey
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type - %s", block.Type)
+	}
+
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("public key is not an RSA key")
+	}
+
+	return rsaKey, nil
+}
+
+// DecodeSigningKey - function to get PEM public key or certificate from signingKey which may be base64 encoded (once or more)
+func DecodeSigningKey(signingKey string) (string, error) {
+	decoded := strings.TrimSpace(signingKey)
+
+	for i := 0; i < 3; i++ {
+		if strings.HasPrefix(decoded, "-----BEGIN") {
+			return decoded, nil
+		}
+
+		data, err := DecodeBase64String(decoded)
+		if err != nil {
+			return "", fmt.Errorf("signingKey is neither PEM nor base64 - %v", err)
+		}
+		decoded = strings.TrimSpace(data)
+	}
+
+	if strings.HasPrefix(decoded, "-----BEGIN") {
+		return decoded, nil
+	}
+
+	return "", fmt.Errorf("signingKey doesn't contain PEM data")
+}
+
+// ParseCertificates - function to parse one or more PEM certificates
+func ParseCertificates(certificates string) ([]*x509.Certificate, error) {
+	var result []*x509.Certificate
+
+	rest := []byte(strings.TrimSpace(certificates))
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, certificate)
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no PEM certificate found")
+	}
+
+	return result, nil
+}
+
+// CertificateFingerprint - function to get hex SHA256 fingerprint of first PEM certificate
+func CertificateFingerprint(certificate string) (string, error) {
+	certificates, err := ParseCertificates(certificate)
+	if err != nil {
+		return "", err
+	}
+
+	return GenerateSha256(string(certificates[0].Raw)), nil
+}
+
+// PublicKeyFingerprint - function to get hex SHA256 fingerprint of DER public key from PEM public key or certificate
+func PublicKeyFingerprint(publicKeyOrCert string) (string, error) {
+	publicKey, err := ParseRsaPublicKey(publicKeyOrCert)
+	if err != nil {
+		return "", err
+	}
+
+	publicKeyDer, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+
+	return GenerateSha256(string(publicKeyDer)), nil
+}
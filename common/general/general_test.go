@@ -3,6 +3,7 @@ package general
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -31,6 +32,10 @@ const (
 	}`
 
 	sampleComposeFolder = "../../samples/tgz"
+
+	samplePrivateKeyPath  = "../../samples/encrypt/private.pem"
+	samplePublicKeyPath   = "../../samples/encrypt/public.pem"
+	sampleCertificatePath = "../../samples/encrypt/certificate.crt"
 )
 
 // Testcase to check if CheckIfEmpty() is able to identify empty variables
@@ -86,6 +91,28 @@ func TestCreateTempFile(t *testing.T) {
 	assert.Equal(t, simpleSampleText, content)
 }
 
+// Testcase to check if CreateBinaryTempFile() keeps the data as is
+func TestCreateBinaryTempFile(t *testing.T) {
+	data := " \n" + simpleSampleText + "\x00\n"
+
+	tmpfile, err := CreateBinaryTempFile(data)
+	if err != nil {
+		t.Errorf("failed to create temp file - %v", err)
+	}
+
+	content, err := ReadDataFromFile(tmpfile)
+	if err != nil {
+		t.Errorf("failed to read data from file - %v", err)
+	}
+
+	err = RemoveTempFile(tmpfile)
+	if err != nil {
+		t.Errorf("failed to remove file - %v", err)
+	}
+
+	assert.Equal(t, data, content)
+}
+
 // Testcase to check TestRemoveTempFile() removes a file
 func TestRemoveTempFile(t *testing.T) {
 	tmpfile, err := CreateTempFile(simpleSampleText)
@@ -215,6 +242,22 @@ func TestGetEncryptPassWorkload(t *testing.T) {
 	assert.Equal(t, b, "k")
 }
 
+// Testcase to check if GetEncryptPassWorkloadWithError() fetches password and data from envelope and returns error for malformed data
+func TestGetEncryptPassWorkloadWithError(t *testing.T) {
+	a, b, err := GetEncryptPassWorkloadWithError("hyper-protect-basic.c2FzaHdhdA==.aw==")
+	if err != nil {
+		t.Errorf("failed to get encrypted password and workload - %v", err)
+	}
+
+	assert.Equal(t, a, "c2FzaHdhdA==")
+	assert.Equal(t, b, "aw==")
+
+	for _, malformed := range []string{"", "hyper-protect-basic", "hyper-protect-basic.c2FzaHdhdA==", "hyper-protect-basic.sashwat.k"} {
+		_, _, err = GetEncryptPassWorkloadWithError(malformed)
+		assert.Error(t, err, malformed)
+	}
+}
+
 func TestCheckUrlExists(t *testing.T) {
 	result, err := CheckUrlExists(certificateDownloadUrl)
 	if err != nil {
@@ -258,6 +301,28 @@ func TestGenerateTgzBase64(t *testing.T) {
 	assert.NotEmpty(t, result)
 }
 
+// Testcase to check if ReadTgzBase64() is able to read files from base64 of tgz
+func TestReadTgzBase64(t *testing.T) {
+	filesFoldersList, err := ListFoldersAndFiles(sampleComposeFolder)
+	if err != nil {
+		t.Errorf("failed to list files and folders - %v", err)
+	}
+
+	tgzBase64, err := GenerateTgzBase64(filesFoldersList)
+	if err != nil {
+		t.Errorf("failed to generate TGZ base64 - %v", err)
+	}
+
+	result, err := ReadTgzBase64(tgzBase64)
+	if err != nil {
+		t.Errorf("failed to read TGZ base64 - %v", err)
+	}
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, "docker-compose.yaml", result[0].Name)
+	assert.Contains(t, result[0].Content, "hello-world")
+}
+
 func TestVerifyContractWithSchema(t *testing.T) {
 	contract, err := ReadDataFromFile(simpleContractPath)
 	if err != nil {
@@ -269,3 +334,126 @@ func TestVerifyContractWithSchema(t *testing.T) {
 		t.Errorf("schema verification failed - %v", err)
 	}
 }
+
+// Testcase to check if ParseRsaPrivateKey() is able to parse PEM private key
+func TestParseRsaPrivateKey(t *testing.T) {
+	privateKey, err := ReadDataFromFile(samplePrivateKeyPath)
+	if err != nil {
+		t.Errorf("failed to read private key - %v", err)
+	}
+
+	result, err := ParseRsaPrivateKey(privateKey)
+	if err != nil {
+		t.Errorf("failed to parse private key - %v", err)
+	}
+
+	assert.Equal(t, 4096, result.N.BitLen())
+}
+
+// Testcase to check if ParseRsaPublicKey() returns the same key from public key and certificate
+func TestParseRsaPublicKey(t *testing.T) {
+	publicKey, err := ReadDataFromFile(samplePublicKeyPath)
+	if err != nil {
+		t.Errorf("failed to read public key - %v", err)
+	}
+
+	certificate, err := ReadDataFromFile(sampleCertificatePath)
+	if err != nil {
+		t.Errorf("failed to read certificate - %v", err)
+	}
+
+	keyFromPublicKey, err := ParseRsaPublicKey(publicKey)
+	if err != nil {
+		t.Errorf("failed to parse public key - %v", err)
+	}
+
+	keyFromCertificate, err := ParseRsaPublicKey(certificate)
+	if err != nil {
+		t.Errorf("failed to parse certificate - %v", err)
+	}
+
+	assert.True(t, keyFromPublicKey.Equal(keyFromCertificate))
+}
+
+// Testcase to check if DecodeSigningKey() returns PEM from plain and base64 encoded signing key
+func TestDecodeSigningKey(t *testing.T) {
+	publicKey, err := ReadDataFromFile(samplePublicKeyPath)
+	if err != nil {
+		t.Errorf("failed to read public key - %v", err)
+	}
+
+	for _, signingKey := range []string{publicKey, EncodeToBase64(publicKey), EncodeToBase64(EncodeToBase64(publicKey))} {
+		result, err := DecodeSigningKey(signingKey)
+		if err != nil {
+			t.Errorf("failed to decode signing key - %v", err)
+		}
+
+		assert.Equal(t, strings.TrimSpace(publicKey), result)
+	}
+
+	_, err = DecodeSigningKey(EncodeToBase64(simpleSampleText))
+	assert.Error(t, err)
+}
+
+// Testcase to check if ParseCertificates() parses all certificates in PEM data
+func TestParseCertificates(t *testing.T) {
+	certificate, err := ReadDataFromFile(sampleCertificatePath)
+	if err != nil {
+		t.Errorf("failed to read certificate - %v", err)
+	}
+
+	result, err := ParseCertificates(certificate + "\n" + cert.EncryptionCertificate)
+	if err != nil {
+		t.Errorf("failed to parse certificates - %v", err)
+	}
+
+	assert.Len(t, result, 2)
+
+	_, err = ParseCertificates(simpleSampleText)
+	assert.Error(t, err)
+}
+
+// Testcase to check if CertificateFingerprint() returns SHA256 of DER certificate
+func TestCertificateFingerprint(t *testing.T) {
+	certificate, err := ReadDataFromFile(sampleCertificatePath)
+	if err != nil {
+		t.Errorf("failed to read certificate - %v", err)
+	}
+
+	certificates, err := ParseCertificates(certificate)
+	if err != nil {
+		t.Errorf("failed to parse certificate - %v", err)
+	}
+
+	result, err := CertificateFingerprint(certificate)
+	if err != nil {
+		t.Errorf("failed to get fingerprint - %v", err)
+	}
+
+	assert.Equal(t, result, GenerateSha256(string(certificates[0].Raw)))
+}
+
+// Testcase to check if PublicKeyFingerprint() returns same fingerprint for public key and its certificate
+func TestPublicKeyFingerprint(t *testing.T) {
+	publicKey, err := ReadDataFromFile(samplePublicKeyPath)
+	if err != nil {
+		t.Errorf("failed to read public key - %v", err)
+	}
+
+	certificate, err := ReadDataFromFile(sampleCertificatePath)
+	if err != nil {
+		t.Errorf("failed to read certificate - %v", err)
+	}
+
+	publicKeyFingerprint, err := PublicKeyFingerprint(publicKey)
+	if err != nil {
+		t.Errorf("failed to get fingerprint of public key - %v", err)
+	}
+
+	certificateFingerprint, err := PublicKeyFingerprint(certificate)
+	if err != nil {
+		t.Errorf("failed to get fingerprint of certificate - %v", err)
+	}
+
+	assert.Equal(t, publicKeyFingerprint, certificateFingerprint)
+}
@@ -0,0 +1,130 @@
+package general
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// HpcrIgnoreFile - file in archived folder with gitignore syntax listing files to leave out of tgz
+	HpcrIgnoreFile = ".hpcrignore"
+)
+
+// ignorePattern - one line of ignore file
+type ignorePattern struct {
+	segments []string
+	negate   bool
+	dirOnly  bool
+}
+
+// ignoreMatcher - list of ignore patterns where the last matching pattern wins
+type ignoreMatcher struct {
+	patterns []ignorePattern
+}
+
+// readIgnoreFile - function to read patterns of ignore file in folder, missing file gives no patterns
+func readIgnoreFile(folderPath string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(folderPath, HpcrIgnoreFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Split(string(data), "\n"), nil
+}
+
+// newIgnoreMatcher - function to parse patterns with gitignore syntax
+func newIgnoreMatcher(lines []string) *ignoreMatcher {
+	matcher := &ignoreMatcher{}
+
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var pattern ignorePattern
+
+		if strings.HasPrefix(line, "!") {
+			pattern.negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, `\`) {
+			line = line[1:]
+		}
+
+		if strings.HasSuffix(line, "/") {
+			pattern.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+
+		// patterns without slash match at any level, patterns with slash are relative to folder
+		if !strings.Contains(line, "/") {
+			line = "**/" + line
+		}
+
+		line = strings.TrimPrefix(line, "/")
+		if line == "" {
+			continue
+		}
+
+		pattern.segments = strings.Split(line, "/")
+		matcher.patterns = append(matcher.patterns, pattern)
+	}
+
+	return matcher
+}
+
+// match - function to check if slash separated relative path is ignored
+func (m *ignoreMatcher) match(relPath string, isDir bool) bool {
+	ignored := false
+	segments := strings.Split(relPath, "/")
+
+	for _, pattern := range m.patterns {
+		if pattern.dirOnly && !isDir {
+			continue
+		}
+
+		if matchSegments(pattern.segments, segments) {
+			ignored = !pattern.negate
+		}
+	}
+
+	return ignored
+}
+
+// matchSegments - function to match path segments against pattern segments where ** matches zero or more segments
+func matchSegments(patternSegments, pathSegments []string) bool {
+	if len(patternSegments) == 0 {
+		return len(pathSegments) == 0
+	}
+
+	if patternSegments[0] == "**" {
+		// trailing ** matches everything inside
+		if len(patternSegments) == 1 {
+			return len(pathSegments) > 0
+		}
+
+		for i := 0; i <= len(pathSegments); i++ {
+			if matchSegments(patternSegments[1:], pathSegments[i:]) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(pathSegments) == 0 {
+		return false
+	}
+
+	matched, err := path.Match(patternSegments[0], pathSegments[0])
+	if err != nil || !matched {
+		return false
+	}
+
+	return matchSegments(patternSegments[1:], pathSegments[1:])
+}
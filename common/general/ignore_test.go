@@ -0,0 +1,47 @@
+package general
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Testcase to check if newIgnoreMatcher() follows gitignore semantics
+func TestNewIgnoreMatcher(t *testing.T) {
+	matcher := newIgnoreMatcher([]string{
+		"# comment",
+		"",
+		"*.swp",
+		".env",
+		"node_modules/",
+		"/build",
+		"docs/**/*.md",
+		"!docs/keep/README.md",
+		`\#hash`,
+	})
+
+	testCases := []struct {
+		path    string
+		isDir   bool
+		ignored bool
+	}{
+		{"docker-compose.yaml", false, false},
+		{".file.swp", false, true},
+		{"config/.nginx.conf.swp", false, true},
+		{".env", false, true},
+		{"app/.env", false, true},
+		{"node_modules", true, true},
+		{"app/node_modules", true, true},
+		{"node_modules", false, false},
+		{"build", true, true},
+		{"app/build", true, false},
+		{"docs/guide.md", false, true},
+		{"docs/a/b/guide.md", false, true},
+		{"docs/keep/README.md", false, false},
+		{"#hash", false, true},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.ignored, matcher.match(testCase.path, testCase.isDir), testCase.path)
+	}
+}
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	dec "github.com/Sashwat-K/lib-hpcr/common/decrypt"
+	enc "github.com/Sashwat-K/lib-hpcr/common/encrypt"
+)
+
+// CryptoProvider - interface for the crypto operations used to build and read hyper protect data
+type CryptoProvider interface {
+	// RandomPasswordGenerator - generate random password for symmetric encryption
+	RandomPasswordGenerator() (string, error)
+	// EncryptPassword - wrap password with the encryption certificate and return it as base64
+	EncryptPassword(password, cert string) (string, error)
+	// EncryptString - encrypt data with password and return it as base64
+	EncryptString(password, section string) (string, error)
+	// GeneratePublicKey - generate PEM public key from PEM private key
+	GeneratePublicKey(privateKey string) (string, error)
+	// SignContract - sign encrypted workload and env and return signature as base64
+	SignContract(encryptedWorkload, encryptedEnv, privateKey string) (string, error)
+	// DecryptPassword - unwrap base64 encrypted password with private key
+	DecryptPassword(base64EncryptedData, privateKey string) (string, error)
+	// DecryptWorkload - decrypt base64 encrypted data with password
+	DecryptWorkload(password, encryptedWorkload string) (string, error)
+}
+
+// OpensslProvider - crypto provider that runs the openssl binary
+type OpensslProvider struct{}
+
+// NativeProvider - crypto provider that uses the Go standard library
+type NativeProvider struct{}
+
+var (
+	_ CryptoProvider = OpensslProvider{}
+	_ CryptoProvider = NativeProvider{}
+)
+
+// GetProvider - function to return the given provider or openssl provider if nil
+func GetProvider(cryptoProvider CryptoProvider) CryptoProvider {
+	if cryptoProvider == nil {
+		return OpensslProvider{}
+	}
+
+	return cryptoProvider
+}
+
+// RandomPasswordGenerator - function to generate random password using openssl
+func (OpensslProvider) RandomPasswordGenerator() (string, error) {
+	return enc.RandomPasswordGenerator()
+}
+
+// EncryptPassword - function to encrypt password using openssl
+func (OpensslProvider) EncryptPassword(password, cert string) (string, error) {
+	return enc.EncryptPassword(password, cert)
+}
+
+// EncryptString - function to encrypt string using openssl
+func (OpensslProvider) EncryptString(password, section string) (string, error) {
+	return enc.EncryptString(password, section)
+}
+
+// GeneratePublicKey - function to generate public key using openssl
+func (OpensslProvider) GeneratePublicKey(privateKey string) (string, error) {
+	return enc.GeneratePublicKey(privateKey)
+}
+
+// SignContract - function to sign encrypted contract using openssl
+func (OpensslProvider) SignContract(encryptedWorkload, encryptedEnv, privateKey string) (string, error) {
+	return enc.SignContract(encryptedWorkload, encryptedEnv, privateKey)
+}
+
+// DecryptPassword - function to decrypt password using openssl
+func (OpensslProvider) DecryptPassword(base64EncryptedData, privateKey string) (string, error) {
+	return dec.DecryptPassword(base64EncryptedData, privateKey)
+}
+
+// DecryptWorkload - function to decrypt workload using openssl
+func (OpensslProvider) DecryptWorkload(password, encryptedWorkload string) (string, error) {
+	return dec.DecryptWorkload(password, encryptedWorkload)
+}
+
+// RandomPasswordGenerator - function to generate random password using Go crypto
+func (NativeProvider) RandomPasswordGenerator() (string, error) {
+	return enc.RandomPasswordGeneratorNative()
+}
+
+// EncryptPassword - function to encrypt password using Go crypto
+func (NativeProvider) EncryptPassword(password, cert string) (string, error) {
+	return enc.EncryptPasswordNative(password, cert)
+}
+
+// EncryptString - function to encrypt string using Go crypto
+func (NativeProvider) EncryptString(password, section string) (string, error) {
+	return enc.EncryptStringNative(password, section)
+}
+
+// GeneratePublicKey - function to generate public key using Go crypto
+func (NativeProvider) GeneratePublicKey(privateKey string) (string, error) {
+	return enc.GeneratePublicKeyNative(privateKey)
+}
+
+// SignContract - function to sign encrypted contract using Go crypto
+func (NativeProvider) SignContract(encryptedWorkload, encryptedEnv, privateKey string) (string, error) {
+	return enc.SignContractNative(encryptedWorkload, encryptedEnv, privateKey)
+}
+
+// DecryptPassword - function to decrypt password using Go crypto
+func (NativeProvider) DecryptPassword(base64EncryptedData, privateKey string) (string, error) {
+	return dec.DecryptPasswordNative(base64EncryptedData, privateKey)
+}
+
+// DecryptWorkload - function to decrypt workload using Go crypto
+func (NativeProvider) DecryptWorkload(password, encryptedWorkload string) (string, error) {
+	return dec.DecryptWorkloadNative(password, encryptedWorkload)
+}
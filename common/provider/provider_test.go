@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+)
+
+const (
+	sampleCertificatePath = "../../samples/encrypt/certificate.crt"
+	samplePrivateKeyPath  = "../../samples/encrypt/private.pem"
+	samplePublicKeyPath   = "../../samples/encrypt/public.pem"
+
+	sampleStringData = "sashwatk"
+)
+
+// roundTrip - function to encrypt and decrypt data with given providers
+func roundTrip(t *testing.T, encrypter, decrypter CryptoProvider) {
+	certificate, err := gen.ReadDataFromFile(sampleCertificatePath)
+	if err != nil {
+		t.Errorf("failed to read certificate - %v", err)
+	}
+
+	privateKey, err := gen.ReadDataFromFile(samplePrivateKeyPath)
+	if err != nil {
+		t.Errorf("failed to read private key - %v", err)
+	}
+
+	password, err := encrypter.RandomPasswordGenerator()
+	if err != nil {
+		t.Errorf("failed to generate random password - %v", err)
+	}
+
+	encryptedPassword, err := encrypter.EncryptPassword(password, certificate)
+	if err != nil {
+		t.Errorf("failed to encrypt password - %v", err)
+	}
+
+	encryptedData, err := encrypter.EncryptString(password, sampleStringData)
+	if err != nil {
+		t.Errorf("failed to encrypt string - %v", err)
+	}
+
+	decryptedPassword, err := decrypter.DecryptPassword(encryptedPassword, privateKey)
+	if err != nil {
+		t.Errorf("failed to decrypt password - %v", err)
+	}
+
+	result, err := decrypter.DecryptWorkload(decryptedPassword, encryptedData)
+	if err != nil {
+		t.Errorf("failed to decrypt data - %v", err)
+	}
+
+	assert.Equal(t, sampleStringData, result)
+}
+
+// Testcase to check if GetProvider() falls back to openssl provider
+func TestGetProvider(t *testing.T) {
+	assert.Equal(t, OpensslProvider{}, GetProvider(nil))
+	assert.Equal(t, NativeProvider{}, GetProvider(NativeProvider{}))
+}
+
+// Testcase to check if OpensslProvider can decrypt what it encrypts
+func TestOpensslProvider(t *testing.T) {
+	roundTrip(t, OpensslProvider{}, OpensslProvider{})
+}
+
+// Testcase to check if NativeProvider can decrypt what it encrypts
+func TestNativeProvider(t *testing.T) {
+	roundTrip(t, NativeProvider{}, NativeProvider{})
+}
+
+// Testcase to check if data encrypted by one provider can be decrypted by the other
+func TestProviderInterop(t *testing.T) {
+	roundTrip(t, OpensslProvider{}, NativeProvider{})
+	roundTrip(t, NativeProvider{}, OpensslProvider{})
+}
+
+// Testcase to check if both providers generate the same public key and signature
+func TestProviderSigning(t *testing.T) {
+	privateKey, err := gen.ReadDataFromFile(samplePrivateKeyPath)
+	if err != nil {
+		t.Errorf("failed to read private key - %v", err)
+	}
+
+	publicKey, err := gen.ReadDataFromFile(samplePublicKeyPath)
+	if err != nil {
+		t.Errorf("failed to read public key - %v", err)
+	}
+
+	for _, cryptoProvider := range []CryptoProvider{OpensslProvider{}, NativeProvider{}} {
+		result, err := cryptoProvider.GeneratePublicKey(privateKey)
+		if err != nil {
+			t.Errorf("failed to generate public key - %v", err)
+		}
+
+		assert.Equal(t, publicKey, result)
+	}
+
+	opensslSignature, err := OpensslProvider{}.SignContract(sampleStringData, sampleStringData, privateKey)
+	if err != nil {
+		t.Errorf("failed to sign contract - %v", err)
+	}
+
+	nativeSignature, err := NativeProvider{}.SignContract(sampleStringData, sampleStringData, privateKey)
+	if err != nil {
+		t.Errorf("failed to sign contract - %v", err)
+	}
+
+	assert.Equal(t, opensslSignature, nativeSignature)
+}
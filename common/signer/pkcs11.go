@@ -0,0 +1,257 @@
+//go:build cgo
+
+package signer
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/big"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+)
+
+var (
+	// digestInfoPrefix - DER prefix of DigestInfo for PKCS#1 v1.5 signatures with CKM_RSA_PKCS
+	digestInfoPrefix = map[crypto.Hash][]byte{
+		crypto.SHA256: {0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x01, 0x05, 0x00, 0x04, 0x20},
+		crypto.SHA384: {0x30, 0x41, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x02, 0x05, 0x00, 0x04, 0x30},
+		crypto.SHA512: {0x30, 0x51, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x03, 0x05, 0x00, 0x04, 0x40},
+	}
+)
+
+// Pkcs11Config - settings to locate the signing key in a PKCS#11 token
+type Pkcs11Config struct {
+	// ModulePath - path of PKCS#11 library (eg: /usr/lib/softhsm/libsofthsm2.so)
+	ModulePath string
+	// TokenLabel - label of token holding the key
+	TokenLabel string
+	// Pin - user PIN of token
+	Pin string
+	// KeyLabel - CKA_LABEL of the RSA private key (optional if KeyId is set)
+	KeyLabel string
+	// KeyId - CKA_ID of the RSA private key as hex (optional if KeyLabel is set)
+	KeyId string
+}
+
+// Pkcs11Signer - signer that signs with an RSA private key held in a PKCS#11 token
+type Pkcs11Signer struct {
+	ctx        *pkcs11.Ctx
+	session    pkcs11.SessionHandle
+	privateKey pkcs11.ObjectHandle
+	publicKey  *rsa.PublicKey
+	mu         sync.Mutex
+}
+
+var _ Signer = (*Pkcs11Signer)(nil)
+
+// NewPkcs11Signer - function to open PKCS#11 session and find signing key
+func NewPkcs11Signer(config Pkcs11Config) (*Pkcs11Signer, error) {
+	if config.ModulePath == "" || config.TokenLabel == "" || (config.KeyLabel == "" && config.KeyId == "") {
+		return nil, fmt.Errorf("module path, token label and key label or key ID are required")
+	}
+
+	ctx := pkcs11.New(config.ModulePath)
+	if ctx == nil {
+		return nil, fmt.Errorf("failed to load PKCS#11 module - %s", config.ModulePath)
+	}
+
+	err := ctx.Initialize()
+	if err != nil {
+		ctx.Destroy()
+		return nil, fmt.Errorf("failed to initialize PKCS#11 module - %v", err)
+	}
+
+	s := &Pkcs11Signer{ctx: ctx}
+
+	err = s.open(config)
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// open - function to login to token and load key handles
+func (s *Pkcs11Signer) open(config Pkcs11Config) error {
+	slot, err := findSlot(s.ctx, config.TokenLabel)
+	if err != nil {
+		return err
+	}
+
+	s.session, err = s.ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return fmt.Errorf("failed to open PKCS#11 session - %v", err)
+	}
+
+	err = s.ctx.Login(s.session, pkcs11.CKU_USER, config.Pin)
+	if err != nil && err != pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN) {
+		return fmt.Errorf("failed to login to token - %v", err)
+	}
+
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_RSA),
+	}
+	if config.KeyLabel != "" {
+		template = append(template, pkcs11.NewAttribute(pkcs11.CKA_LABEL, config.KeyLabel))
+	}
+	if config.KeyId != "" {
+		keyId, err := hex.DecodeString(config.KeyId)
+		if err != nil {
+			return fmt.Errorf("failed to decode key ID - %v", err)
+		}
+		template = append(template, pkcs11.NewAttribute(pkcs11.CKA_ID, keyId))
+	}
+
+	s.privateKey, err = findObject(s.ctx, s.session, pkcs11.CKO_PRIVATE_KEY, template)
+	if err != nil {
+		return err
+	}
+
+	// RSA private keys normally expose modulus and exponent, otherwise fall back to the public key object
+	s.publicKey, err = readPublicKey(s.ctx, s.session, s.privateKey)
+	if err != nil {
+		publicKeyHandle, findErr := findObject(s.ctx, s.session, pkcs11.CKO_PUBLIC_KEY, template)
+		if findErr != nil {
+			return fmt.Errorf("failed to read public key - %v", err)
+		}
+
+		s.publicKey, err = readPublicKey(s.ctx, s.session, publicKeyHandle)
+		if err != nil {
+			return fmt.Errorf("failed to read public key - %v", err)
+		}
+	}
+
+	return nil
+}
+
+// Public - function to get public key of signing key
+func (s *Pkcs11Signer) Public() crypto.PublicKey {
+	return s.publicKey
+}
+
+// Sign - function to create PKCS#1 v1.5 signature of digest inside the token
+func (s *Pkcs11Signer) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if _, ok := opts.(*rsa.PSSOptions); ok {
+		return nil, fmt.Errorf("RSA-PSS signatures are not supported")
+	}
+
+	prefix, ok := digestInfoPrefix[opts.HashFunc()]
+	if !ok {
+		return nil, fmt.Errorf("unsupported hash function - %v", opts.HashFunc())
+	}
+
+	if len(digest) != opts.HashFunc().Size() {
+		return nil, fmt.Errorf("digest length doesn't match hash function")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ctx == nil {
+		return nil, fmt.Errorf("PKCS#11 signer is closed")
+	}
+
+	err := s.ctx.SignInit(s.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_RSA_PKCS, nil)}, s.privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize signing - %v", err)
+	}
+
+	signature, err := s.ctx.Sign(s.session, append(append([]byte{}, prefix...), digest...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign - %v", err)
+	}
+
+	return signature, nil
+}
+
+// Close - function to logout and release PKCS#11 module
+func (s *Pkcs11Signer) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ctx == nil {
+		return nil
+	}
+
+	if s.session != 0 {
+		s.ctx.Logout(s.session)
+		s.ctx.CloseSession(s.session)
+	}
+
+	err := s.ctx.Finalize()
+	s.ctx.Destroy()
+	s.ctx = nil
+
+	return err
+}
+
+// findSlot - function to find slot of token with given label
+func findSlot(ctx *pkcs11.Ctx, tokenLabel string) (uint, error) {
+	slots, err := ctx.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list PKCS#11 slots - %v", err)
+	}
+
+	for _, slot := range slots {
+		tokenInfo, err := ctx.GetTokenInfo(slot)
+		if err != nil {
+			continue
+		}
+
+		if tokenInfo.Label == tokenLabel {
+			return slot, nil
+		}
+	}
+
+	return 0, fmt.Errorf("token not found - %s", tokenLabel)
+}
+
+// findObject - function to find exactly one object of given class matching template
+func findObject(ctx *pkcs11.Ctx, session pkcs11.SessionHandle, class uint, template []*pkcs11.Attribute) (pkcs11.ObjectHandle, error) {
+	template = append([]*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_CLASS, class)}, template...)
+
+	err := ctx.FindObjectsInit(session, template)
+	if err != nil {
+		return 0, fmt.Errorf("failed to search PKCS#11 objects - %v", err)
+	}
+
+	handles, _, err := ctx.FindObjects(session, 2)
+	ctx.FindObjectsFinal(session)
+	if err != nil {
+		return 0, fmt.Errorf("failed to search PKCS#11 objects - %v", err)
+	}
+
+	if len(handles) == 0 {
+		return 0, fmt.Errorf("key not found in token")
+	}
+	if len(handles) > 1 {
+		return 0, fmt.Errorf("more than one key matches label and ID")
+	}
+
+	return handles[0], nil
+}
+
+// readPublicKey - function to read RSA modulus and exponent of key object
+func readPublicKey(ctx *pkcs11.Ctx, session pkcs11.SessionHandle, handle pkcs11.ObjectHandle) (*rsa.PublicKey, error) {
+	attributes, err := ctx.GetAttributeValue(session, handle, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_MODULUS, nil),
+		pkcs11.NewAttribute(pkcs11.CKA_PUBLIC_EXPONENT, nil),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(attributes) != 2 || len(attributes[0].Value) == 0 || len(attributes[1].Value) == 0 {
+		return nil, fmt.Errorf("modulus or public exponent is missing")
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(attributes[0].Value),
+		E: int(new(big.Int).SetBytes(attributes[1].Value).Int64()),
+	}, nil
+}
@@ -0,0 +1,144 @@
+//go:build cgo
+
+package signer
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"os"
+	"testing"
+
+	"github.com/miekg/pkcs11"
+	"github.com/stretchr/testify/assert"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+)
+
+const (
+	sampleHsmKeyLabel = "lib-hpcr-test-signing-key"
+)
+
+// softHsmConfig - function to get SoftHSM settings from environment, test is skipped if they are missing
+//
+// eg: softhsm2-util --init-token --free --label lib-hpcr --pin 1234 --so-pin 1234
+//
+//	PKCS11_MODULE=/usr/lib/softhsm/libsofthsm2.so PKCS11_TOKEN_LABEL=lib-hpcr PKCS11_PIN=1234 go test ./common/signer
+func softHsmConfig(t *testing.T) Pkcs11Config {
+	config := Pkcs11Config{
+		ModulePath: os.Getenv("PKCS11_MODULE"),
+		TokenLabel: os.Getenv("PKCS11_TOKEN_LABEL"),
+		Pin:        os.Getenv("PKCS11_PIN"),
+		KeyLabel:   sampleHsmKeyLabel,
+	}
+
+	if config.ModulePath == "" || config.TokenLabel == "" {
+		t.Skip("PKCS11_MODULE and PKCS11_TOKEN_LABEL are not set")
+	}
+
+	return config
+}
+
+// generateHsmKey - function to generate RSA key pair with sample label in token if it doesn't exist
+func generateHsmKey(t *testing.T, config Pkcs11Config) {
+	ctx := pkcs11.New(config.ModulePath)
+	if ctx == nil {
+		t.Fatalf("failed to load PKCS#11 module")
+	}
+	defer ctx.Destroy()
+
+	err := ctx.Initialize()
+	if err != nil {
+		t.Fatalf("failed to initialize PKCS#11 module - %v", err)
+	}
+	defer ctx.Finalize()
+
+	slot, err := findSlot(ctx, config.TokenLabel)
+	if err != nil {
+		t.Fatalf("failed to find token - %v", err)
+	}
+
+	session, err := ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+	if err != nil {
+		t.Fatalf("failed to open session - %v", err)
+	}
+	defer ctx.CloseSession(session)
+
+	err = ctx.Login(session, pkcs11.CKU_USER, config.Pin)
+	if err != nil {
+		t.Fatalf("failed to login - %v", err)
+	}
+	defer ctx.Logout(session)
+
+	_, err = findObject(ctx, session, pkcs11.CKO_PRIVATE_KEY, []*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_LABEL, config.KeyLabel)})
+	if err == nil {
+		return
+	}
+
+	_, _, err = ctx.GenerateKeyPair(session,
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_RSA_PKCS_KEY_PAIR_GEN, nil)},
+		[]*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_VERIFY, true),
+			pkcs11.NewAttribute(pkcs11.CKA_MODULUS_BITS, 4096),
+			pkcs11.NewAttribute(pkcs11.CKA_PUBLIC_EXPONENT, []byte{1, 0, 1}),
+			pkcs11.NewAttribute(pkcs11.CKA_LABEL, config.KeyLabel),
+		},
+		[]*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+			pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+			pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, false),
+			pkcs11.NewAttribute(pkcs11.CKA_LABEL, config.KeyLabel),
+		})
+	if err != nil {
+		t.Fatalf("failed to generate key pair - %v", err)
+	}
+}
+
+// Testcase to check if Pkcs11Signer signs contract with key held in SoftHSM
+func TestPkcs11Signer(t *testing.T) {
+	config := softHsmConfig(t)
+	generateHsmKey(t, config)
+
+	contractSigner, err := NewPkcs11Signer(config)
+	if err != nil {
+		t.Fatalf("failed to create PKCS#11 signer - %v", err)
+	}
+	defer contractSigner.Close()
+
+	result, err := SignContract(sampleEncryptedWorkload, sampleEncryptedEnv, contractSigner)
+	if err != nil {
+		t.Errorf("failed to sign contract - %v", err)
+	}
+
+	signature, err := gen.DecodeBase64String(result)
+	if err != nil {
+		t.Errorf("failed to decode signature - %v", err)
+	}
+
+	digest := sha256.Sum256([]byte(sampleEncryptedWorkload + sampleEncryptedEnv))
+	err = rsa.VerifyPKCS1v15(contractSigner.Public().(*rsa.PublicKey), crypto.SHA256, digest[:], []byte(signature))
+
+	assert.NoError(t, err)
+}
+
+// Testcase to check if NewPkcs11Signer() validates configuration
+func TestNewPkcs11SignerConfig(t *testing.T) {
+	_, err := NewPkcs11Signer(Pkcs11Config{})
+
+	assert.Error(t, err)
+}
+
+// Testcase to check if Sign() returns error instead of panicking after Close()
+func TestPkcs11SignerClosed(t *testing.T) {
+	contractSigner := &Pkcs11Signer{}
+
+	assert.NoError(t, contractSigner.Close())
+
+	digest := sha256.Sum256([]byte(sampleEncryptedWorkload))
+	_, err := contractSigner.Sign(nil, digest[:], crypto.SHA256)
+
+	assert.ErrorContains(t, err, "closed")
+}
@@ -0,0 +1,120 @@
+package signer
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+)
+
+// Signer - interface for keys that sign contracts, compatible with crypto.Signer so the private key can stay in a HSM
+type Signer interface {
+	crypto.Signer
+}
+
+// NewPemSigner - function to create signer from PEM private key
+func NewPemSigner(privateKey string) (Signer, error) {
+	rsaKey, err := gen.ParseRsaPrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key - %v", err)
+	}
+
+	return rsaKey, nil
+}
+
+// PublicKeyPem - function to get PEM public key of signer
+func PublicKeyPem(contractSigner Signer) (string, error) {
+	if contractSigner == nil {
+		return "", fmt.Errorf("signer is missing")
+	}
+
+	publicKeyDer, err := x509.MarshalPKIXPublicKey(contractSigner.Public())
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal public key - %v", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyDer})), nil
+}
+
+// SignContract - function to sign encrypted contract with signer
+func SignContract(encryptedWorkload, encryptedEnv string, contractSigner Signer) (string, error) {
+	if contractSigner == nil {
+		return "", fmt.Errorf("signer is missing")
+	}
+
+	digest := sha256.Sum256([]byte(encryptedWorkload + encryptedEnv))
+
+	workloadEnvSignature, err := contractSigner.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign contract - %v", err)
+	}
+
+	return gen.EncodeToBase64(string(workloadEnvSignature)), nil
+}
+
+// CreateCsr - function to create PEM CSR signed by signer from CSR parameters JSON
+func CreateCsr(csrData string, contractSigner Signer) (string, error) {
+	if contractSigner == nil {
+		return "", fmt.Errorf("signer is missing")
+	}
+
+	var csrDataMap map[string]string
+	err := json.Unmarshal([]byte(csrData), &csrDataMap)
+	if err != nil {
+		return "", fmt.Errorf("failed to unmarshal JSON - %v", err)
+	}
+
+	template := &x509.CertificateRequest{
+		Subject: pkix.Name{
+			Country:            []string{csrDataMap["country"]},
+			Province:           []string{csrDataMap["state"]},
+			Locality:           []string{csrDataMap["location"]},
+			Organization:       []string{csrDataMap["org"]},
+			OrganizationalUnit: []string{csrDataMap["unit"]},
+			CommonName:         csrDataMap["domain"],
+		},
+		EmailAddresses: []string{csrDataMap["mail"]},
+	}
+
+	csrDer, err := x509.CreateCertificateRequest(rand.Reader, template, contractSigner)
+	if err != nil {
+		return "", fmt.Errorf("failed to create CSR - %v", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDer})), nil
+}
+
+// VerifyContract - function to verify signature of encrypted contract with PEM public key or signing certificate
+func VerifyContract(encryptedWorkload, encryptedEnv, workloadEnvSignature, signingKey string) error {
+	signingKeyPem, err := gen.DecodeSigningKey(signingKey)
+	if err != nil {
+		return fmt.Errorf("failed to decode signing key - %v", err)
+	}
+
+	publicKey, err := gen.ParseRsaPublicKey(signingKeyPem)
+	if err != nil {
+		return fmt.Errorf("failed to parse signing key - %v", err)
+	}
+
+	signature, err := gen.DecodeBase64String(strings.TrimSpace(workloadEnvSignature))
+	if err != nil {
+		return fmt.Errorf("failed to decode signature - %v", err)
+	}
+
+	digest := sha256.Sum256([]byte(encryptedWorkload + encryptedEnv))
+
+	err = rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], []byte(signature))
+	if err != nil {
+		return fmt.Errorf("signature verification failed - %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package signer
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	enc "github.com/Sashwat-K/lib-hpcr/common/encrypt"
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+)
+
+const (
+	samplePrivateKeyPath = "../../samples/encrypt/private.pem"
+	samplePublicKeyPath  = "../../samples/encrypt/public.pem"
+
+	sampleEncryptedWorkload = "hyper-protect-basic.a.b"
+	sampleEncryptedEnv      = "hyper-protect-basic.c.d"
+)
+
+var (
+	sampleCsrParams = map[string]string{
+		"country":  "IN",
+		"state":    "Karnataka",
+		"location": "Bangalore",
+		"org":      "IBM",
+		"unit":     "ISDL",
+		"domain":   "HPVS",
+		"mail":     "sashwat.k@ibm.com",
+	}
+)
+
+// pemSigner - function to create signer from sample private key
+func pemSigner(t *testing.T) (Signer, string) {
+	privateKey, err := gen.ReadDataFromFile(samplePrivateKeyPath)
+	if err != nil {
+		t.Errorf("failed to read private key - %v", err)
+	}
+
+	contractSigner, err := NewPemSigner(privateKey)
+	if err != nil {
+		t.Fatalf("failed to create signer - %v", err)
+	}
+
+	return contractSigner, privateKey
+}
+
+// Testcase to check if NewPemSigner() rejects invalid private key
+func TestNewPemSigner(t *testing.T) {
+	_, err := NewPemSigner("invalid")
+
+	assert.Error(t, err)
+}
+
+// Testcase to check if PublicKeyPem() returns the public key of signer
+func TestPublicKeyPem(t *testing.T) {
+	contractSigner, _ := pemSigner(t)
+
+	publicKey, err := gen.ReadDataFromFile(samplePublicKeyPath)
+	if err != nil {
+		t.Errorf("failed to read public key - %v", err)
+	}
+
+	result, err := PublicKeyPem(contractSigner)
+	if err != nil {
+		t.Errorf("failed to get public key - %v", err)
+	}
+
+	assert.Equal(t, publicKey, result)
+}
+
+// Testcase to check if SignContract() generates the same signature as signing with PEM private key
+func TestSignContract(t *testing.T) {
+	contractSigner, privateKey := pemSigner(t)
+
+	expected, err := enc.SignContractNative(sampleEncryptedWorkload, sampleEncryptedEnv, privateKey)
+	if err != nil {
+		t.Errorf("failed to sign contract - %v", err)
+	}
+
+	result, err := SignContract(sampleEncryptedWorkload, sampleEncryptedEnv, contractSigner)
+	if err != nil {
+		t.Errorf("failed to sign contract - %v", err)
+	}
+
+	assert.Equal(t, expected, result)
+}
+
+// Testcase to check if CreateCsr() generates CSR signed by signer
+func TestCreateCsr(t *testing.T) {
+	contractSigner, _ := pemSigner(t)
+
+	csrParams, err := json.Marshal(sampleCsrParams)
+	if err != nil {
+		t.Errorf("failed to marshal CSR parameters - %v", err)
+	}
+
+	result, err := CreateCsr(string(csrParams), contractSigner)
+	if err != nil {
+		t.Errorf("failed to create CSR - %v", err)
+	}
+
+	block, _ := pem.Decode([]byte(result))
+	if block == nil {
+		t.Fatalf("failed to decode CSR")
+	}
+
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		t.Errorf("failed to parse CSR - %v", err)
+	}
+
+	assert.NoError(t, csr.CheckSignature())
+	assert.Equal(t, sampleCsrParams["domain"], csr.Subject.CommonName)
+}
+
+// Testcase to check if VerifyContract() accepts valid signature and rejects tampered data
+func TestVerifyContract(t *testing.T) {
+	contractSigner, _ := pemSigner(t)
+
+	publicKey, err := gen.ReadDataFromFile(samplePublicKeyPath)
+	if err != nil {
+		t.Errorf("failed to read public key - %v", err)
+	}
+
+	signature, err := SignContract(sampleEncryptedWorkload, sampleEncryptedEnv, contractSigner)
+	if err != nil {
+		t.Errorf("failed to sign contract - %v", err)
+	}
+
+	assert.NoError(t, VerifyContract(sampleEncryptedWorkload, sampleEncryptedEnv, signature, publicKey))
+	assert.NoError(t, VerifyContract(sampleEncryptedWorkload, sampleEncryptedEnv, signature, gen.EncodeToBase64(publicKey)))
+	assert.Error(t, VerifyContract(sampleEncryptedEnv, sampleEncryptedWorkload, signature, publicKey))
+}
@@ -0,0 +1,151 @@
+package contract
+
+import (
+	"crypto"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	enc "github.com/Sashwat-K/lib-hpcr/common/encrypt"
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+	sign "github.com/Sashwat-K/lib-hpcr/common/signer"
+)
+
+const (
+	signingBundleVersion         = "1"
+	signingBundleDigestAlgorithm = "sha256"
+)
+
+// SigningBundle - to-be-signed data produced by the first phase of detached signing
+type SigningBundle struct {
+	// Version - version of bundle format
+	Version string `json:"version"`
+	// DigestAlgorithm - algorithm of Digest, always sha256
+	DigestAlgorithm string `json:"digestAlgorithm"`
+	// Digest - hex SHA256 of EncryptedWorkload+EncryptedEnv, this is what has to be signed
+	Digest string `json:"digest"`
+	// SigningKey - public key or signing certificate injected into env as signingKey
+	SigningKey string `json:"signingKey"`
+	// EncryptedWorkload - encrypted workload section
+	EncryptedWorkload string `json:"encryptedWorkload"`
+	// EncryptedEnv - encrypted env section
+	EncryptedEnv string `json:"encryptedEnv"`
+}
+
+// HpcrContractSigningBundle - function to encrypt workload and env and generate bundle to be signed on another machine
+func HpcrContractSigningBundle(contract, encryptionCertificate, publicKey string, opts Options) (string, string, string, error) {
+	err := gen.VerifyContractWithSchema(contract)
+	if err != nil {
+		return "", "", "", fmt.Errorf("schema verification failed - %v", err)
+	}
+
+	if gen.CheckIfEmpty(contract, publicKey) {
+		return "", "", "", fmt.Errorf(emptyParameterErrStatement)
+	}
+
+	encryptedWorkload, encryptedEnv, err := encryptSections(contract, encryptionCertificate, publicKey, opts)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	bundle, err := MarshalSigningBundle(SigningBundle{
+		Version:           signingBundleVersion,
+		DigestAlgorithm:   signingBundleDigestAlgorithm,
+		Digest:            gen.GenerateSha256(encryptedWorkload + encryptedEnv),
+		SigningKey:        publicKey,
+		EncryptedWorkload: encryptedWorkload,
+		EncryptedEnv:      encryptedEnv,
+	})
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return bundle, gen.GenerateSha256(contract), gen.GenerateSha256(bundle), nil
+}
+
+// HpcrContractFromSigningBundle - function to verify external signature of bundle and generate final signed contract
+func HpcrContractFromSigningBundle(bundle, workloadEnvSignature string) (string, string, string, error) {
+	if gen.CheckIfEmpty(bundle, workloadEnvSignature) {
+		return "", "", "", fmt.Errorf(emptyParameterErrStatement)
+	}
+
+	signingBundle, err := ParseSigningBundle(bundle)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	err = sign.VerifyContract(signingBundle.EncryptedWorkload, signingBundle.EncryptedEnv, workloadEnvSignature, signingBundle.SigningKey)
+	if err != nil {
+		return "", "", "", fmt.Errorf("signature doesn't match bundle - %v", err)
+	}
+
+	finalContract, err := enc.GenFinalSignedContract(signingBundle.EncryptedWorkload, signingBundle.EncryptedEnv, workloadEnvSignature)
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to generate final contract - %v", err)
+	}
+
+	return finalContract, gen.GenerateSha256(bundle), gen.GenerateSha256(finalContract), nil
+}
+
+// SignSigningBundle - function to sign digest of bundle with signer and return signature as base64
+func SignSigningBundle(bundle string, contractSigner sign.Signer) (string, error) {
+	if gen.CheckIfEmpty(bundle) || contractSigner == nil {
+		return "", fmt.Errorf(emptyParameterErrStatement)
+	}
+
+	signingBundle, err := ParseSigningBundle(bundle)
+	if err != nil {
+		return "", err
+	}
+
+	digest, err := hex.DecodeString(signingBundle.Digest)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode digest - %v", err)
+	}
+
+	signature, err := contractSigner.Sign(rand.Reader, digest, crypto.SHA256)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign digest - %v", err)
+	}
+
+	return gen.EncodeToBase64(string(signature)), nil
+}
+
+// MarshalSigningBundle - function to serialize bundle as JSON
+func MarshalSigningBundle(signingBundle SigningBundle) (string, error) {
+	bundle, err := json.MarshalIndent(signingBundle, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal signing bundle - %v", err)
+	}
+
+	return string(bundle) + "\n", nil
+}
+
+// ParseSigningBundle - function to parse bundle and check that digest matches encrypted sections
+func ParseSigningBundle(bundle string) (SigningBundle, error) {
+	var signingBundle SigningBundle
+
+	err := json.Unmarshal([]byte(bundle), &signingBundle)
+	if err != nil {
+		return SigningBundle{}, fmt.Errorf("failed to unmarshal signing bundle - %v", err)
+	}
+
+	if signingBundle.Version != signingBundleVersion {
+		return SigningBundle{}, fmt.Errorf("unsupported signing bundle version - %s", signingBundle.Version)
+	}
+
+	if signingBundle.DigestAlgorithm != signingBundleDigestAlgorithm {
+		return SigningBundle{}, fmt.Errorf("unsupported digest algorithm - %s", signingBundle.DigestAlgorithm)
+	}
+
+	if gen.CheckIfEmpty(signingBundle.EncryptedWorkload, signingBundle.EncryptedEnv, signingBundle.SigningKey) {
+		return SigningBundle{}, fmt.Errorf("signing bundle is incomplete")
+	}
+
+	if gen.GenerateSha256(signingBundle.EncryptedWorkload+signingBundle.EncryptedEnv) != signingBundle.Digest {
+		return SigningBundle{}, fmt.Errorf("digest doesn't match encrypted workload and env")
+	}
+
+	return signingBundle, nil
+}
@@ -0,0 +1,108 @@
+package contract
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	enc "github.com/Sashwat-K/lib-hpcr/common/encrypt"
+	prov "github.com/Sashwat-K/lib-hpcr/common/provider"
+	sign "github.com/Sashwat-K/lib-hpcr/common/signer"
+)
+
+// signingBundle - function to generate signing bundle from sample contract
+func signingBundle(t *testing.T) (string, string) {
+	contract, privateKey, publicKey, _, _, err := common("TestEncryptWrapper")
+	if err != nil {
+		t.Errorf("failed to get contract, private key and public key - %v", err)
+	}
+
+	bundle, inputSha256, _, err := HpcrContractSigningBundle(contract, "", publicKey, Options{Provider: prov.NativeProvider{}})
+	if err != nil {
+		t.Fatalf("failed to generate signing bundle - %v", err)
+	}
+
+	assert.Equal(t, simpleContractInputChecksum, inputSha256)
+
+	return bundle, privateKey
+}
+
+// Testcase to check if HpcrContractSigningBundle() generates bundle with digest of encrypted sections
+func TestHpcrContractSigningBundle(t *testing.T) {
+	bundle, _ := signingBundle(t)
+
+	result, err := ParseSigningBundle(bundle)
+	if err != nil {
+		t.Errorf("failed to parse signing bundle - %v", err)
+	}
+
+	assert.Contains(t, result.EncryptedWorkload, hpcrEncryptPrefix)
+	assert.Contains(t, result.EncryptedEnv, hpcrEncryptPrefix)
+	assert.Contains(t, result.SigningKey, "PUBLIC KEY")
+}
+
+// Testcase to check if HpcrContractFromSigningBundle() assembles contract from bundle and detached signature
+func TestHpcrContractFromSigningBundle(t *testing.T) {
+	bundle, privateKey := signingBundle(t)
+
+	signingBundle, err := ParseSigningBundle(bundle)
+	if err != nil {
+		t.Errorf("failed to parse signing bundle - %v", err)
+	}
+
+	signature, err := enc.SignContract(signingBundle.EncryptedWorkload, signingBundle.EncryptedEnv, privateKey)
+	if err != nil {
+		t.Errorf("failed to sign contract - %v", err)
+	}
+
+	result, _, _, err := HpcrContractFromSigningBundle(bundle, signature)
+	if err != nil {
+		t.Errorf("failed to generate contract from signing bundle - %v", err)
+	}
+
+	assert.Contains(t, result, "envWorkloadSignature: "+signature)
+
+	_, _, _, err = HpcrContractFromSigningBundle(bundle, sampleBase64Data)
+	assert.Error(t, err)
+}
+
+// Testcase to check if SignSigningBundle() generates signature accepted by HpcrContractFromSigningBundle()
+func TestSignSigningBundle(t *testing.T) {
+	bundle, privateKey := signingBundle(t)
+
+	contractSigner, err := sign.NewPemSigner(privateKey)
+	if err != nil {
+		t.Fatalf("failed to create signer - %v", err)
+	}
+
+	signature, err := SignSigningBundle(bundle, contractSigner)
+	if err != nil {
+		t.Errorf("failed to sign bundle - %v", err)
+	}
+
+	_, _, _, err = HpcrContractFromSigningBundle(bundle, signature)
+
+	assert.NoError(t, err)
+}
+
+// Testcase to check if ParseSigningBundle() rejects bundle whose sections were modified
+func TestParseSigningBundle(t *testing.T) {
+	bundle, _ := signingBundle(t)
+
+	signingBundle, err := ParseSigningBundle(bundle)
+	if err != nil {
+		t.Errorf("failed to parse signing bundle - %v", err)
+	}
+
+	signingBundle.EncryptedEnv = strings.Replace(signingBundle.EncryptedEnv, hpcrEncryptPrefix, hpcrEncryptPrefix+"A", 1)
+
+	tampered, err := MarshalSigningBundle(signingBundle)
+	if err != nil {
+		t.Errorf("failed to marshal signing bundle - %v", err)
+	}
+
+	_, err = ParseSigningBundle(tampered)
+
+	assert.Error(t, err)
+}
@@ -2,17 +2,28 @@ package contract
 
 import (
 	"fmt"
+	"io/fs"
 
 	"gopkg.in/yaml.v3"
 
 	enc "github.com/Sashwat-K/lib-hpcr/common/encrypt"
 	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+	prov "github.com/Sashwat-K/lib-hpcr/common/provider"
+	sign "github.com/Sashwat-K/lib-hpcr/common/signer"
 )
 
 const (
 	emptyParameterErrStatement = "required parameter is empty"
 )
 
+// Options - optional settings for contract generation
+type Options struct {
+	// Provider - crypto implementation to use, openssl is used if nil
+	Provider prov.CryptoProvider
+	// Tgz - settings for archives generated by HpcrTgz functions
+	Tgz gen.TgzOptions
+}
+
 // HpcrText - function to generate base64 data and checksum from string
 func HpcrText(plainText string) (string, string, string, error) {
 	if gen.CheckIfEmpty(plainText) {
@@ -37,11 +48,16 @@ func HpcrJson(plainJson string) (string, string, string, error) {
 
 // HpcrTextEncrypted - function to generate encrypted Hyper protect data and SHA256 from plain text
 func HpcrTextEncrypted(plainText, encryptionCertificate string) (string, string, string, error) {
+	return HpcrTextEncryptedWithOptions(plainText, encryptionCertificate, Options{})
+}
+
+// HpcrTextEncryptedWithOptions - function to generate encrypted Hyper protect data and SHA256 from plain text with given options
+func HpcrTextEncryptedWithOptions(plainText, encryptionCertificate string, opts Options) (string, string, string, error) {
 	if gen.CheckIfEmpty(plainText) {
 		return "", "", "", fmt.Errorf(emptyParameterErrStatement)
 	}
 
-	hpcrTextEncryptedStr, err := Encrypter(plainText, encryptionCertificate)
+	hpcrTextEncryptedStr, err := EncrypterWithOptions(plainText, encryptionCertificate, opts)
 	if err != nil {
 		return "", "", "", fmt.Errorf("failed to generate encrypted string - %v", err)
 	}
@@ -51,11 +67,16 @@ func HpcrTextEncrypted(plainText, encryptionCertificate string) (string, string,
 
 // HpcrJsonEncrypted - function to generate encrypted hyper protect data and SHA256 from plain JSON data
 func HpcrJsonEncrypted(plainJson, encryptionCertificate string) (string, string, string, error) {
+	return HpcrJsonEncryptedWithOptions(plainJson, encryptionCertificate, Options{})
+}
+
+// HpcrJsonEncryptedWithOptions - function to generate encrypted hyper protect data and SHA256 from plain JSON data with given options
+func HpcrJsonEncryptedWithOptions(plainJson, encryptionCertificate string, opts Options) (string, string, string, error) {
 	if !gen.IsJSON(plainJson) {
 		return "", "", "", fmt.Errorf("contract is not a JSON data")
 	}
 
-	hpcrJsonEncrypted, err := Encrypter(plainJson, encryptionCertificate)
+	hpcrJsonEncrypted, err := EncrypterWithOptions(plainJson, encryptionCertificate, opts)
 	if err != nil {
 		return "", "", "", fmt.Errorf("failed to generate encrypted JSON - %v", err)
 	}
@@ -65,48 +86,157 @@ func HpcrJsonEncrypted(plainJson, encryptionCertificate string) (string, string,
 
 // HpcrTgz - function to generate base64 of tar.tgz which was prepared from docker compose/podman files
 func HpcrTgz(folderPath string) (string, string, string, error) {
+	return HpcrTgzWithOptions(folderPath, Options{})
+}
+
+// HpcrTgzWithOptions - function to generate base64 of tar.tgz with given options (eg: reproducible archive)
+// Input checksum is the SHA256 of the content manifest of folder
+func HpcrTgzWithOptions(folderPath string, opts Options) (string, string, string, error) {
+	tgzBase64, _, manifestSha256, outputSha256, err := HpcrTgzWithManifest(folderPath, opts)
+
+	return tgzBase64, manifestSha256, outputSha256, err
+}
+
+// HpcrTgzWithManifest - function to generate base64 of tar.tgz and manifest of path, mode and SHA256 of each file in it
+func HpcrTgzWithManifest(folderPath string, opts Options) (string, string, string, string, error) {
 	if gen.CheckIfEmpty(folderPath) {
-		return "", "", "", fmt.Errorf(emptyParameterErrStatement)
+		return "", "", "", "", fmt.Errorf(emptyParameterErrStatement)
 	}
 
 	if !gen.CheckFileFolderExists(folderPath) {
-		return "", "", "", fmt.Errorf("folder doesn't exists - %s", folderPath)
+		return "", "", "", "", fmt.Errorf("folder doesn't exists - %s", folderPath)
 	}
 
 	filesFoldersList, err := gen.ListFoldersAndFiles(folderPath)
 	if err != nil {
-		return "", "", "", fmt.Errorf("failed to get files and folder under path - %v", err)
+		return "", "", "", "", fmt.Errorf("failed to get files and folder under path - %v", err)
 	}
 
-	tgzBase64, err := gen.GenerateTgzBase64(filesFoldersList)
+	tgzBase64, manifest, err := gen.GenerateTgzBase64AndManifestWithOptions(filesFoldersList, opts.Tgz)
 	if err != nil {
-		return "", "", "", fmt.Errorf("failed to get base64 tgz - %v", err)
+		return "", "", "", "", fmt.Errorf("failed to get base64 tgz - %v", err)
 	}
 
-	return tgzBase64, gen.GenerateSha256(folderPath), gen.GenerateSha256(tgzBase64), nil
+	return tgzBase64, manifest, gen.GenerateSha256(manifest), gen.GenerateSha256(tgzBase64), nil
 }
 
 // HpcrTgzEncrypted - function to generate encrypted tgz
 func HpcrTgzEncrypted(folderPath, encryptionCertificate string) (string, string, string, error) {
-	if gen.CheckIfEmpty(folderPath) {
-		return "", "", "", fmt.Errorf(emptyParameterErrStatement)
+	return HpcrTgzEncryptedWithOptions(folderPath, encryptionCertificate, Options{})
+}
+
+// HpcrTgzEncryptedWithOptions - function to generate encrypted tgz with given options
+func HpcrTgzEncryptedWithOptions(folderPath, encryptionCertificate string, opts Options) (string, string, string, error) {
+	hpcrTgzEncryptedStr, _, manifestSha256, outputSha256, err := HpcrTgzEncryptedWithManifest(folderPath, encryptionCertificate, opts)
+
+	return hpcrTgzEncryptedStr, manifestSha256, outputSha256, err
+}
+
+// HpcrTgzEncryptedWithManifest - function to generate encrypted tgz and manifest of path, mode and SHA256 of each file in it
+func HpcrTgzEncryptedWithManifest(folderPath, encryptionCertificate string, opts Options) (string, string, string, string, error) {
+	tgzBase64, manifest, _, _, err := HpcrTgzWithManifest(folderPath, opts)
+	if err != nil {
+		return "", "", "", "", err
+	}
+
+	return encryptTgz(tgzBase64, manifest, encryptionCertificate, opts)
+}
+
+// HpcrTgzFromFS - function to generate base64 of tar.tgz from files in fs.FS (eg: embed.FS)
+// Input checksum is the SHA256 of the content manifest of files
+func HpcrTgzFromFS(fsys fs.FS, opts Options) (string, string, string, error) {
+	tgzBase64, _, manifestSha256, outputSha256, err := HpcrTgzFromFSWithManifest(fsys, opts)
+
+	return tgzBase64, manifestSha256, outputSha256, err
+}
+
+// HpcrTgzFromFSWithManifest - function to generate base64 of tar.tgz and manifest of files in fs.FS
+func HpcrTgzFromFSWithManifest(fsys fs.FS, opts Options) (string, string, string, string, error) {
+	if fsys == nil {
+		return "", "", "", "", fmt.Errorf(emptyParameterErrStatement)
 	}
 
-	tgzBase64, _, _, err := HpcrTgz(folderPath)
+	tgzBase64, manifest, err := gen.GenerateTgzBase64AndManifestFromFS(fsys, opts.Tgz)
 	if err != nil {
-		return "", "", "", err
+		return "", "", "", "", fmt.Errorf("failed to get base64 tgz - %v", err)
 	}
 
-	hpcrTgzEncryptedStr, err := Encrypter(tgzBase64, encryptionCertificate)
+	return tgzBase64, manifest, gen.GenerateSha256(manifest), gen.GenerateSha256(tgzBase64), nil
+}
+
+// HpcrTgzFromMap - function to generate base64 of tar.tgz from in-memory files (slash separated path to content)
+// Input checksum is the SHA256 of the content manifest of files
+func HpcrTgzFromMap(files map[string]string, opts Options) (string, string, string, error) {
+	tgzBase64, _, manifestSha256, outputSha256, err := HpcrTgzFromMapWithManifest(files, opts)
+
+	return tgzBase64, manifestSha256, outputSha256, err
+}
+
+// HpcrTgzFromMapWithManifest - function to generate base64 of tar.tgz and manifest of in-memory files
+func HpcrTgzFromMapWithManifest(files map[string]string, opts Options) (string, string, string, string, error) {
+	if len(files) == 0 {
+		return "", "", "", "", fmt.Errorf(emptyParameterErrStatement)
+	}
+
+	tgzBase64, manifest, err := gen.GenerateTgzBase64AndManifestFromMap(files, opts.Tgz)
 	if err != nil {
-		return "", "", "", fmt.Errorf("failed to generate encrypted tgz - %v", err)
+		return "", "", "", "", fmt.Errorf("failed to get base64 tgz - %v", err)
 	}
 
-	return hpcrTgzEncryptedStr, gen.GenerateSha256(folderPath), gen.GenerateSha256(hpcrTgzEncryptedStr), nil
+	return tgzBase64, manifest, gen.GenerateSha256(manifest), gen.GenerateSha256(tgzBase64), nil
+}
+
+// HpcrTgzEncryptedFromFS - function to generate encrypted tgz from files in fs.FS
+func HpcrTgzEncryptedFromFS(fsys fs.FS, encryptionCertificate string, opts Options) (string, string, string, error) {
+	hpcrTgzEncryptedStr, _, manifestSha256, outputSha256, err := HpcrTgzEncryptedFromFSWithManifest(fsys, encryptionCertificate, opts)
+
+	return hpcrTgzEncryptedStr, manifestSha256, outputSha256, err
+}
+
+// HpcrTgzEncryptedFromFSWithManifest - function to generate encrypted tgz and manifest of files in fs.FS
+func HpcrTgzEncryptedFromFSWithManifest(fsys fs.FS, encryptionCertificate string, opts Options) (string, string, string, string, error) {
+	tgzBase64, manifest, _, _, err := HpcrTgzFromFSWithManifest(fsys, opts)
+	if err != nil {
+		return "", "", "", "", err
+	}
+
+	return encryptTgz(tgzBase64, manifest, encryptionCertificate, opts)
+}
+
+// HpcrTgzEncryptedFromMap - function to generate encrypted tgz from in-memory files
+func HpcrTgzEncryptedFromMap(files map[string]string, encryptionCertificate string, opts Options) (string, string, string, error) {
+	hpcrTgzEncryptedStr, _, manifestSha256, outputSha256, err := HpcrTgzEncryptedFromMapWithManifest(files, encryptionCertificate, opts)
+
+	return hpcrTgzEncryptedStr, manifestSha256, outputSha256, err
+}
+
+// HpcrTgzEncryptedFromMapWithManifest - function to generate encrypted tgz and manifest of in-memory files
+func HpcrTgzEncryptedFromMapWithManifest(files map[string]string, encryptionCertificate string, opts Options) (string, string, string, string, error) {
+	tgzBase64, manifest, _, _, err := HpcrTgzFromMapWithManifest(files, opts)
+	if err != nil {
+		return "", "", "", "", err
+	}
+
+	return encryptTgz(tgzBase64, manifest, encryptionCertificate, opts)
+}
+
+// encryptTgz - function to encrypt base64 of tgz and return it with its manifest and checksums
+func encryptTgz(tgzBase64, manifest, encryptionCertificate string, opts Options) (string, string, string, string, error) {
+	hpcrTgzEncryptedStr, err := EncrypterWithOptions(tgzBase64, encryptionCertificate, opts)
+	if err != nil {
+		return "", "", "", "", fmt.Errorf("failed to generate encrypted tgz - %v", err)
+	}
+
+	return hpcrTgzEncryptedStr, manifest, gen.GenerateSha256(manifest), gen.GenerateSha256(hpcrTgzEncryptedStr), nil
 }
 
 // HpcrContractSignedEncrypted - function to generate Signed and Encrypted contract
 func HpcrContractSignedEncrypted(contract, encryptionCertificate, privateKey string) (string, string, string, error) {
+	return HpcrContractSignedEncryptedWithOptions(contract, encryptionCertificate, privateKey, Options{})
+}
+
+// HpcrContractSignedEncryptedWithOptions - function to generate Signed and Encrypted contract with given options
+func HpcrContractSignedEncryptedWithOptions(contract, encryptionCertificate, privateKey string, opts Options) (string, string, string, error) {
 	err := gen.VerifyContractWithSchema(contract)
 	if err != nil {
 		return "", "", "", fmt.Errorf("schema verification failed - %v", err)
@@ -118,12 +248,12 @@ func HpcrContractSignedEncrypted(contract, encryptionCertificate, privateKey str
 
 	encryptCertificate := gen.FetchEncryptionCertificate(encryptionCertificate)
 
-	publicKey, err := enc.GeneratePublicKey(privateKey)
+	publicKey, err := prov.GetProvider(opts.Provider).GeneratePublicKey(privateKey)
 	if err != nil {
 		return "", "", "", fmt.Errorf("failed to generate public key - %v", err)
 	}
 
-	signedEncryptContract, err := EncryptWrapper(contract, encryptCertificate, privateKey, publicKey)
+	signedEncryptContract, err := EncryptWrapperWithOptions(contract, encryptCertificate, privateKey, publicKey, opts)
 	if err != nil {
 		return "", "", "", fmt.Errorf("failed to sign and encrypt contract - %v", err)
 	}
@@ -133,6 +263,12 @@ func HpcrContractSignedEncrypted(contract, encryptionCertificate, privateKey str
 
 // HpcrContractSignedEncryptedContractExpiry - function to generate sign with contract expiry enabled and encrypt contract (with CSR parameters and CSR file)
 func HpcrContractSignedEncryptedContractExpiry(contract, encryptionCertificate, privateKey, cacert, caKey, csrDataStr, csrPemData string, expiryDays int) (string, string, string, error) {
+	return HpcrContractSignedEncryptedContractExpiryWithOptions(contract, encryptionCertificate, privateKey, cacert, caKey, csrDataStr, csrPemData, expiryDays, Options{})
+}
+
+// HpcrContractSignedEncryptedContractExpiryWithOptions - function to generate sign with contract expiry enabled and encrypt contract with given options
+// The crypto provider encrypts and signs the contract, the signing certificate is always created with openssl
+func HpcrContractSignedEncryptedContractExpiryWithOptions(contract, encryptionCertificate, privateKey, cacert, caKey, csrDataStr, csrPemData string, expiryDays int, opts Options) (string, string, string, error) {
 	err := gen.VerifyContractWithSchema(contract)
 	if err != nil {
 		return "", "", "", fmt.Errorf("schema verification failed - %v", err)
@@ -151,7 +287,61 @@ func HpcrContractSignedEncryptedContractExpiry(contract, encryptionCertificate,
 		return "", "", "", fmt.Errorf("failed to generate signing certificate - %v", err)
 	}
 
-	finalContract, err := EncryptWrapper(contract, encryptionCertificate, privateKey, signingCert)
+	finalContract, err := EncryptWrapperWithOptions(contract, encryptionCertificate, privateKey, signingCert, opts)
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to generate signed and encrypted contract - %v", err)
+	}
+
+	return finalContract, gen.GenerateSha256(contract), gen.GenerateSha256(finalContract), nil
+}
+
+// HpcrContractSignedEncryptedWithSigner - function to generate Signed and Encrypted contract where signing key is held by signer (eg: HSM)
+func HpcrContractSignedEncryptedWithSigner(contract, encryptionCertificate string, contractSigner sign.Signer, opts Options) (string, string, string, error) {
+	err := gen.VerifyContractWithSchema(contract)
+	if err != nil {
+		return "", "", "", fmt.Errorf("schema verification failed - %v", err)
+	}
+
+	if gen.CheckIfEmpty(contract) || contractSigner == nil {
+		return "", "", "", fmt.Errorf(emptyParameterErrStatement)
+	}
+
+	signedEncryptContract, err := EncryptWrapperWithSigner(contract, encryptionCertificate, contractSigner, "", opts)
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to sign and encrypt contract - %v", err)
+	}
+
+	return signedEncryptContract, gen.GenerateSha256(contract), gen.GenerateSha256(signedEncryptContract), nil
+}
+
+// HpcrContractSignedEncryptedContractExpiryWithSigner - function to generate sign with contract expiry enabled and encrypt contract where signing key is held by signer
+func HpcrContractSignedEncryptedContractExpiryWithSigner(contract, encryptionCertificate string, contractSigner sign.Signer, cacert, caKey, csrDataStr, csrPemData string, expiryDays int, opts Options) (string, string, string, error) {
+	err := gen.VerifyContractWithSchema(contract)
+	if err != nil {
+		return "", "", "", fmt.Errorf("schema verification failed - %v", err)
+	}
+
+	if gen.CheckIfEmpty(contract, cacert, caKey) || contractSigner == nil {
+		return "", "", "", fmt.Errorf(emptyParameterErrStatement)
+	}
+
+	if csrPemData == "" && csrDataStr == "" || len(csrPemData) > 0 && len(csrDataStr) > 0 {
+		return "", "", "", fmt.Errorf("the CSR parameters and CSR PEM file are parsed together or both are nil")
+	}
+
+	if csrPemData == "" {
+		csrPemData, err = sign.CreateCsr(csrDataStr, contractSigner)
+		if err != nil {
+			return "", "", "", fmt.Errorf("failed to generate CSR - %v", err)
+		}
+	}
+
+	signingCert, err := enc.CreateSigningCert("", cacert, caKey, "", csrPemData, expiryDays)
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to generate signing certificate - %v", err)
+	}
+
+	finalContract, err := EncryptWrapperWithSigner(contract, encryptionCertificate, contractSigner, signingCert, opts)
 	if err != nil {
 		return "", "", "", fmt.Errorf("failed to generate signed and encrypted contract - %v", err)
 	}
@@ -161,71 +351,158 @@ func HpcrContractSignedEncryptedContractExpiry(contract, encryptionCertificate,
 
 // EncryptWrapper - wrapper function to sign (with and without contract expiry) and encrypt contract
 func EncryptWrapper(contract, encryptionCertificate, privateKey, publicKey string) (string, error) {
+	return EncryptWrapperWithOptions(contract, encryptionCertificate, privateKey, publicKey, Options{})
+}
+
+// EncryptWrapperWithOptions - wrapper function to sign and encrypt contract with given options
+func EncryptWrapperWithOptions(contract, encryptionCertificate, privateKey, publicKey string, opts Options) (string, error) {
 	if gen.CheckIfEmpty(contract, privateKey, publicKey) {
 		return "", fmt.Errorf(emptyParameterErrStatement)
 	}
 
-	var contractMap map[string]interface{}
+	return encryptWrapper(contract, encryptionCertificate, publicKey, func(encryptedWorkload, encryptedEnv string) (string, error) {
+		return prov.GetProvider(opts.Provider).SignContract(encryptedWorkload, encryptedEnv, privateKey)
+	}, opts)
+}
 
-	encryptCertificate := gen.FetchEncryptionCertificate(encryptionCertificate)
+// EncryptWrapperWithSigner - wrapper function to sign contract with signer and encrypt contract
+// The public key of signer is injected as signingKey if publicKey is empty
+func EncryptWrapperWithSigner(contract, encryptionCertificate string, contractSigner sign.Signer, publicKey string, opts Options) (string, error) {
+	if gen.CheckIfEmpty(contract) || contractSigner == nil {
+		return "", fmt.Errorf(emptyParameterErrStatement)
+	}
+
+	if publicKey == "" {
+		signerPublicKey, err := sign.PublicKeyPem(contractSigner)
+		if err != nil {
+			return "", fmt.Errorf("failed to get public key of signer - %v", err)
+		}
+		publicKey = signerPublicKey
+	}
+
+	return encryptWrapper(contract, encryptionCertificate, publicKey, func(encryptedWorkload, encryptedEnv string) (string, error) {
+		return sign.SignContract(encryptedWorkload, encryptedEnv, contractSigner)
+	}, opts)
+}
+
+// encryptWrapper - function to encrypt workload and env, inject signingKey and sign using given sign function
+func encryptWrapper(contract, encryptionCertificate, publicKey string, signContract func(encryptedWorkload, encryptedEnv string) (string, error), opts Options) (string, error) {
+	encryptedWorkload, encryptedEnv, err := encryptSections(contract, encryptionCertificate, publicKey, opts)
+	if err != nil {
+		return "", err
+	}
 
+	workloadEnvSignature, err := signContract(encryptedWorkload, encryptedEnv)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign contract - %v", err)
+	}
+
+	finalContract, err := enc.GenFinalSignedContract(encryptedWorkload, encryptedEnv, workloadEnvSignature)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate final contract - %v", err)
+	}
+
+	return addAttestationPublicKey(contract, finalContract)
+}
+
+// addAttestationPublicKey - function to copy top level attestationPublicKey of contract to final contract, as it is not part of workload or env
+func addAttestationPublicKey(contract, finalContract string) (string, error) {
+	var contractMap map[string]interface{}
 	err := yaml.Unmarshal([]byte(contract), &contractMap)
 	if err != nil {
 		return "", fmt.Errorf("failed to unmarshal YAML - %v", err)
 	}
 
-	workloadData, err := gen.MapToYaml(contractMap["workload"].(map[string]interface{}))
+	attestationPublicKey, ok := contractMap["attestationPublicKey"]
+	if !ok {
+		return finalContract, nil
+	}
+
+	var finalContractMap map[string]interface{}
+	err = yaml.Unmarshal([]byte(finalContract), &finalContractMap)
 	if err != nil {
-		return "", fmt.Errorf("failed to convert MAP to YAML - %v", err)
+		return "", fmt.Errorf("failed to unmarshal final contract - %v", err)
 	}
 
-	encryptedWorkload, err := Encrypter(workloadData, encryptCertificate)
+	finalContractMap["attestationPublicKey"] = attestationPublicKey
+
+	finalContract, err = gen.MapToYaml(finalContractMap)
 	if err != nil {
-		return "", fmt.Errorf("failed to encrypt workload - %v", err)
+		return "", fmt.Errorf("failed to generate final contract - %v", err)
 	}
 
-	updatedEnv, err := gen.KeyValueInjector(contractMap["env"].(map[string]interface{}), "signingKey", gen.EncodeToBase64(publicKey))
+	return finalContract, nil
+}
+
+// encryptSections - function to encrypt workload and env after injecting signingKey to env
+func encryptSections(contract, encryptionCertificate, publicKey string, opts Options) (string, string, error) {
+	var contractMap map[string]interface{}
+
+	encryptCertificate := gen.FetchEncryptionCertificate(encryptionCertificate)
+
+	err := yaml.Unmarshal([]byte(contract), &contractMap)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to unmarshal YAML - %v", err)
+	}
+
+	workloadMap, ok := contractMap["workload"].(map[string]interface{})
+	if !ok {
+		return "", "", fmt.Errorf("workload is missing in contract or is not a map")
+	}
+
+	envMap, ok := contractMap["env"].(map[string]interface{})
+	if !ok {
+		return "", "", fmt.Errorf("env is missing in contract or is not a map")
+	}
+
+	workloadData, err := gen.MapToYaml(workloadMap)
 	if err != nil {
-		return "", fmt.Errorf("failed to inject signingKey to env - %v", err)
+		return "", "", fmt.Errorf("failed to convert MAP to YAML - %v", err)
 	}
 
-	encryptedEnv, err := Encrypter(updatedEnv, encryptCertificate)
+	encryptedWorkload, err := EncrypterWithOptions(workloadData, encryptCertificate, opts)
 	if err != nil {
-		return "", fmt.Errorf("failed to encrypt env - %v", err)
+		return "", "", fmt.Errorf("failed to encrypt workload - %v", err)
 	}
 
-	workloadEnvSignature, err := enc.SignContract(encryptedWorkload, encryptedEnv, privateKey)
+	updatedEnv, err := gen.KeyValueInjector(envMap, "signingKey", gen.EncodeToBase64(publicKey))
 	if err != nil {
-		return "", fmt.Errorf("failed to sign contract - %v", err)
+		return "", "", fmt.Errorf("failed to inject signingKey to env - %v", err)
 	}
 
-	finalContract, err := enc.GenFinalSignedContract(encryptedWorkload, encryptedEnv, workloadEnvSignature)
+	encryptedEnv, err := EncrypterWithOptions(updatedEnv, encryptCertificate, opts)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate final contract - %v", err)
+		return "", "", fmt.Errorf("failed to encrypt env - %v", err)
 	}
 
-	return finalContract, nil
+	return encryptedWorkload, encryptedEnv, nil
 }
 
 // Encrypter - function to generate encrypted hyper protect data from plain string
 func Encrypter(stringText, encryptionCertificate string) (string, error) {
+	return EncrypterWithOptions(stringText, encryptionCertificate, Options{})
+}
+
+// EncrypterWithOptions - function to generate encrypted hyper protect data from plain string with given options
+func EncrypterWithOptions(stringText, encryptionCertificate string, opts Options) (string, error) {
 	if gen.CheckIfEmpty(stringText) {
 		return "", fmt.Errorf(emptyParameterErrStatement)
 	}
 
 	encCert := gen.FetchEncryptionCertificate(encryptionCertificate)
+	cryptoProvider := prov.GetProvider(opts.Provider)
 
-	password, err := enc.RandomPasswordGenerator()
+	password, err := cryptoProvider.RandomPasswordGenerator()
 	if err != nil {
 		return "", fmt.Errorf("failed to generate random password - %v", err)
 	}
 
-	encodedEncryptedPassword, err := enc.EncryptPassword(password, encCert)
+	encodedEncryptedPassword, err := cryptoProvider.EncryptPassword(password, encCert)
 	if err != nil {
 		return "", fmt.Errorf("failed to encrypt password - %v", err)
 	}
 
-	encryptedString, err := enc.EncryptString(password, stringText)
+	encryptedString, err := cryptoProvider.EncryptString(password, stringText)
 	if err != nil {
 		return "", fmt.Errorf("failed to encrypt key - %v", err)
 	}
@@ -2,15 +2,19 @@ package contract
 
 import (
 	"encoding/json"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/Sashwat-K/lib-hpcr/common/envelope"
 	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+	prov "github.com/Sashwat-K/lib-hpcr/common/provider"
+	sign "github.com/Sashwat-K/lib-hpcr/common/signer"
 )
 
 const (
-	hpcrEncryptPrefix = "hyper-protect-basic."
+	hpcrEncryptPrefix = envelope.Scheme + "."
 
 	sampleStringData     = "sashwatk"
 	sampleBase64Data     = "c2FzaHdhdGs="
@@ -27,7 +31,7 @@ const (
 	sampleOutputChecksumJson = "0e282874a193587be1d2aca98083e9ebbddc840edc964a130a215bd674f8487e"
 
 	sampleComposeFolderPath     = "../samples/tgz"
-	sampleComposeFolderChecksum = "3e4a006b9422a3fbf8c58d4f1dbac4494b34f800ddbb6e048c31709bb0cde599"
+	sampleComposeFolderChecksum = "5331a6e845ccf002af9df8b75b6a07e02dd1f405c200230bd58ed78d88bf3137"
 
 	simpleContractPath          = "../samples/simple_contract.yaml"
 	simpleContractInputChecksum = "072cd6d89d9d253a0426eadea7217aedfe86197bfb8a5b4873386fcaa72ddfda"
@@ -35,6 +39,8 @@ const (
 	samplePrivateKeyPath = "../samples/encrypt/private.pem"
 	samplePublicKeyPath  = "../samples/encrypt/public.pem"
 
+	samplePublicKeyCertPath = "../samples/encrypt/certificate.crt"
+
 	sampleCePrivateKeyPath   = "../samples/contract-expiry/private.pem"
 	sampleCeCaCertPath       = "../samples/contract-expiry/personal_ca.crt"
 	sampleCeCaKeyPath        = "../samples/contract-expiry/personal_ca.pem"
@@ -54,6 +60,27 @@ var (
 	}
 )
 
+// fakeProvider - crypto provider that doesn't need openssl or real keys
+type fakeProvider struct{}
+
+func (fakeProvider) RandomPasswordGenerator() (string, error) { return "password", nil }
+func (fakeProvider) EncryptPassword(password, cert string) (string, error) {
+	return gen.EncodeToBase64(password), nil
+}
+func (fakeProvider) EncryptString(password, section string) (string, error) {
+	return gen.EncodeToBase64(section), nil
+}
+func (fakeProvider) GeneratePublicKey(privateKey string) (string, error) { return "publicKey", nil }
+func (fakeProvider) SignContract(encryptedWorkload, encryptedEnv, privateKey string) (string, error) {
+	return "signature", nil
+}
+func (fakeProvider) DecryptPassword(base64EncryptedData, privateKey string) (string, error) {
+	return gen.DecodeBase64String(base64EncryptedData)
+}
+func (fakeProvider) DecryptWorkload(password, encryptedWorkload string) (string, error) {
+	return gen.DecodeBase64String(encryptedWorkload)
+}
+
 // common - common function to pull data from files
 func common(testType string) (string, string, string, string, string, error) {
 	contract, err := gen.ReadDataFromFile(simpleContractPath)
@@ -131,6 +158,17 @@ func TestHpcrTextEncrypted(t *testing.T) {
 	assert.Equal(t, inputSha256, sampleInputChecksum)
 }
 
+// Testcase to check if HpcrTextEncryptedWithOptions() uses the given crypto provider
+func TestHpcrTextEncryptedWithOptions(t *testing.T) {
+	result, inputSha256, _, err := HpcrTextEncryptedWithOptions(sampleStringData, "", Options{Provider: fakeProvider{}})
+	if err != nil {
+		t.Errorf("failed to generate HPCR encrypted text - %v", err)
+	}
+
+	assert.Equal(t, result, hpcrEncryptPrefix+"cGFzc3dvcmQ=."+sampleBase64Data)
+	assert.Equal(t, inputSha256, sampleInputChecksum)
+}
+
 // Testcase to check if TestHpcrJsonEncrypted() is able to encrypt JSON and generate SHA256
 func TestHpcrJsonEncrypted(t *testing.T) {
 	result, inputSha256, _, err := HpcrJsonEncrypted(sampleStringJson, "")
@@ -142,6 +180,17 @@ func TestHpcrJsonEncrypted(t *testing.T) {
 	assert.Equal(t, inputSha256, sampleInputChecksumJson)
 }
 
+// Testcase to check if HpcrJsonEncryptedWithOptions() uses the given crypto provider
+func TestHpcrJsonEncryptedWithOptions(t *testing.T) {
+	result, inputSha256, _, err := HpcrJsonEncryptedWithOptions(sampleStringJson, "", Options{Provider: fakeProvider{}})
+	if err != nil {
+		t.Errorf("failed to generate HPCR encrypted JSON - %v", err)
+	}
+
+	assert.Equal(t, result, hpcrEncryptPrefix+"cGFzc3dvcmQ=."+sampleBase64Json)
+	assert.Equal(t, inputSha256, sampleInputChecksumJson)
+}
+
 // Testcase to check if HpcrTgz() is able to generate base64 of tar.tgz
 func TestHpcrTgz(t *testing.T) {
 	result, inputSha256, _, err := HpcrTgz(sampleComposeFolderPath)
@@ -163,6 +212,156 @@ func TestHpcrTgzEncrypted(t *testing.T) {
 	assert.Equal(t, inputSha256, sampleComposeFolderChecksum)
 }
 
+// Testcase to check if HpcrTgzWithOptions() generates same archive on every run in reproducible mode
+func TestHpcrTgzWithOptions(t *testing.T) {
+	opts := Options{Tgz: gen.TgzOptions{Reproducible: true}}
+
+	result, inputSha256, outputSha256, err := HpcrTgzWithOptions(sampleComposeFolderPath, opts)
+	if err != nil {
+		t.Errorf("failed to generate HPCR TGZ - %v", err)
+	}
+
+	_, _, secondOutputSha256, err := HpcrTgzWithOptions(sampleComposeFolderPath, opts)
+	if err != nil {
+		t.Errorf("failed to generate HPCR TGZ - %v", err)
+	}
+
+	assert.NotEmpty(t, result)
+	assert.Equal(t, inputSha256, sampleComposeFolderChecksum)
+	assert.Equal(t, outputSha256, secondOutputSha256)
+}
+
+// Testcase to check if HpcrTgzWithManifest() returns manifest of files in tgz and its checksum
+func TestHpcrTgzWithManifest(t *testing.T) {
+	result, manifest, manifestSha256, _, err := HpcrTgzWithManifest(sampleComposeFolderPath, Options{})
+	if err != nil {
+		t.Errorf("failed to generate HPCR TGZ - %v", err)
+	}
+
+	assert.NotEmpty(t, result)
+	assert.Contains(t, manifest, " 0644 docker-compose.yaml\n")
+	assert.Equal(t, manifestSha256, sampleComposeFolderChecksum)
+	assert.Equal(t, manifestSha256, gen.GenerateSha256(manifest))
+}
+
+// Testcase to check if HpcrTgzEncryptedWithOptions() is able to generate encrypted tgz with given options
+func TestHpcrTgzEncryptedWithOptions(t *testing.T) {
+	result, inputSha256, _, err := HpcrTgzEncryptedWithOptions(sampleComposeFolderPath, "", Options{Provider: prov.NativeProvider{}, Tgz: gen.TgzOptions{Reproducible: true}})
+	if err != nil {
+		t.Errorf("failed to generated HPCR encrypted TGZ - %v", err)
+	}
+
+	assert.Contains(t, result, hpcrEncryptPrefix)
+	assert.Equal(t, inputSha256, sampleComposeFolderChecksum)
+}
+
+// Testcase to check if HpcrTgzFromFS() gives same input checksum as HpcrTgz() for same files
+func TestHpcrTgzFromFS(t *testing.T) {
+	result, inputSha256, _, err := HpcrTgzFromFS(os.DirFS(sampleComposeFolderPath), Options{})
+	if err != nil {
+		t.Errorf("failed to generate HPCR TGZ - %v", err)
+	}
+
+	assert.NotEmpty(t, result)
+	assert.Equal(t, inputSha256, sampleComposeFolderChecksum)
+}
+
+// Testcase to check if HpcrTgzFromMap() gives same input checksum as HpcrTgz() for same files
+func TestHpcrTgzFromMap(t *testing.T) {
+	compose, err := os.ReadFile(sampleComposeFolderPath + "/docker-compose.yaml")
+	if err != nil {
+		t.Errorf("failed to read compose file - %v", err)
+	}
+
+	result, inputSha256, _, err := HpcrTgzFromMap(map[string]string{"docker-compose.yaml": string(compose)}, Options{})
+	if err != nil {
+		t.Errorf("failed to generate HPCR TGZ - %v", err)
+	}
+
+	assert.NotEmpty(t, result)
+	assert.Equal(t, inputSha256, sampleComposeFolderChecksum)
+}
+
+// Testcase to check if HpcrTgzEncryptedFromFS() is able to generate encrypted tgz from fs.FS
+func TestHpcrTgzEncryptedFromFS(t *testing.T) {
+	result, inputSha256, _, err := HpcrTgzEncryptedFromFS(os.DirFS(sampleComposeFolderPath), "", Options{Provider: prov.NativeProvider{}})
+	if err != nil {
+		t.Errorf("failed to generated HPCR encrypted TGZ - %v", err)
+	}
+
+	assert.Contains(t, result, hpcrEncryptPrefix)
+	assert.Equal(t, inputSha256, sampleComposeFolderChecksum)
+}
+
+// Testcase to check if HpcrTgzEncryptedFromMap() is able to generate encrypted tgz from in-memory files
+func TestHpcrTgzEncryptedFromMap(t *testing.T) {
+	result, _, _, err := HpcrTgzEncryptedFromMap(map[string]string{"docker-compose.yaml": "services: {}"}, "", Options{Provider: prov.NativeProvider{}})
+	if err != nil {
+		t.Errorf("failed to generated HPCR encrypted TGZ - %v", err)
+	}
+
+	assert.Contains(t, result, hpcrEncryptPrefix)
+}
+
+// Testcase to check if encrypted, fs.FS and in-memory variants return same manifest as HpcrTgzWithManifest()
+func TestHpcrTgzVariantsWithManifest(t *testing.T) {
+	_, expectedManifest, _, _, err := HpcrTgzWithManifest(sampleComposeFolderPath, Options{})
+	if err != nil {
+		t.Errorf("failed to generate HPCR TGZ - %v", err)
+	}
+
+	compose, err := os.ReadFile(sampleComposeFolderPath + "/docker-compose.yaml")
+	if err != nil {
+		t.Errorf("failed to read compose file - %v", err)
+	}
+
+	files := map[string]string{"docker-compose.yaml": string(compose)}
+	opts := Options{Provider: prov.NativeProvider{}}
+
+	result, manifest, manifestSha256, _, err := HpcrTgzEncryptedWithManifest(sampleComposeFolderPath, "", opts)
+	if err != nil {
+		t.Errorf("failed to generated HPCR encrypted TGZ - %v", err)
+	}
+
+	assert.Contains(t, result, hpcrEncryptPrefix)
+	assert.Equal(t, manifest, expectedManifest)
+	assert.Equal(t, manifestSha256, sampleComposeFolderChecksum)
+
+	result, manifest, manifestSha256, _, err = HpcrTgzFromFSWithManifest(os.DirFS(sampleComposeFolderPath), opts)
+	if err != nil {
+		t.Errorf("failed to generate HPCR TGZ - %v", err)
+	}
+
+	assert.NotEmpty(t, result)
+	assert.Equal(t, manifest, expectedManifest)
+	assert.Equal(t, manifestSha256, sampleComposeFolderChecksum)
+
+	result, manifest, manifestSha256, _, err = HpcrTgzFromMapWithManifest(files, opts)
+	if err != nil {
+		t.Errorf("failed to generate HPCR TGZ - %v", err)
+	}
+
+	assert.NotEmpty(t, result)
+	assert.Equal(t, manifest, expectedManifest)
+	assert.Equal(t, manifestSha256, sampleComposeFolderChecksum)
+
+	result, manifest, _, _, err = HpcrTgzEncryptedFromFSWithManifest(os.DirFS(sampleComposeFolderPath), "", opts)
+	if err != nil {
+		t.Errorf("failed to generated HPCR encrypted TGZ - %v", err)
+	}
+
+	assert.Contains(t, result, hpcrEncryptPrefix)
+	assert.Equal(t, manifest, expectedManifest)
+
+	result, manifest, _, _, err = HpcrTgzEncryptedFromMapWithManifest(files, "", opts)
+	if err != nil {
+		t.Errorf("failed to generated HPCR encrypted TGZ - %v", err)
+	}
+
+	assert.Contains(t, result, hpcrEncryptPrefix)
+	assert.Equal(t, manifest, expectedManifest)
+}
+
 // Testcase to check if HpcrContractSignedEncrypted() is able to generate
 func TestHpcrContractSignedEncrypted(t *testing.T) {
 
@@ -222,6 +421,28 @@ func TestHpcrContractSignedEncryptedContractExpiryCsrPem(t *testing.T) {
 	assert.Equal(t, inputSha256, simpleContractInputChecksum)
 }
 
+// Testcase to check if HpcrContractSignedEncryptedContractExpiryWithOptions() uses the given crypto provider for encryption and signing
+func TestHpcrContractSignedEncryptedContractExpiryWithOptions(t *testing.T) {
+	contract, privateKey, _, caCert, caKey, err := common("TestHpcrContractSignedEncryptedContractExpiryCsrPem")
+	if err != nil {
+		t.Errorf("failed to get contract, private key, CA certificate and CA key - %v", err)
+	}
+
+	csr, err := gen.ReadDataFromFile(sampleCeCsrPath)
+	if err != nil {
+		t.Errorf("failed to read CSR file - %v", err)
+	}
+
+	result, inputSha256, _, err := HpcrContractSignedEncryptedContractExpiryWithOptions(contract, "", privateKey, caCert, caKey, "", csr, sampleContractExpiryDays, Options{Provider: fakeProvider{}})
+	if err != nil {
+		t.Errorf("failed to generate signed and encrypted contract with contract expiry - %v", err)
+	}
+
+	assert.Contains(t, result, "envWorkloadSignature: signature")
+	assert.Contains(t, result, hpcrEncryptPrefix+"cGFzc3dvcmQ=.")
+	assert.Equal(t, inputSha256, simpleContractInputChecksum)
+}
+
 // Testcase to check if EncryptWrapper() is able to sign and encrypt a contract
 func TestEncryptWrapper(t *testing.T) {
 	contract, privateKey, publicKey, _, _, err := common("TestEncryptWrapper")
@@ -246,3 +467,111 @@ func TestEncrypter(t *testing.T) {
 
 	assert.Contains(t, result, hpcrEncryptPrefix)
 }
+
+// Testcase to check if EncrypterWithOptions() uses the given crypto provider
+func TestEncrypterWithOptions(t *testing.T) {
+	result, err := EncrypterWithOptions(sampleStringData, "", Options{Provider: fakeProvider{}})
+	if err != nil {
+		t.Errorf("failed to encrypt contract - %v", err)
+	}
+
+	assert.Equal(t, hpcrEncryptPrefix+"cGFzc3dvcmQ=."+sampleBase64Data, result)
+}
+
+// Testcase to check if EncryptWrapperWithOptions() uses the given crypto provider for encryption and signing
+func TestEncryptWrapperWithOptions(t *testing.T) {
+	contract, privateKey, publicKey, _, _, err := common("TestEncryptWrapper")
+	if err != nil {
+		t.Errorf("failed to get contract, private key and public key - %v", err)
+	}
+
+	result, err := EncryptWrapperWithOptions(contract, "", privateKey, publicKey, Options{Provider: fakeProvider{}})
+	if err != nil {
+		t.Errorf("failed to sign and encrypt contract - %v", err)
+	}
+
+	assert.Contains(t, result, "envWorkloadSignature: signature")
+}
+
+// Testcase to check if HpcrContractSignedEncryptedWithOptions() is able to generate contract without openssl
+func TestHpcrContractSignedEncryptedWithOptions(t *testing.T) {
+	contract, privateKey, _, _, _, err := common("TestHpcrContractSignedEncrypted")
+	if err != nil {
+		t.Errorf("failed to get contract and private key - %v", err)
+	}
+
+	result, inputSha256, _, err := HpcrContractSignedEncryptedWithOptions(contract, "", privateKey, Options{Provider: prov.NativeProvider{}})
+	if err != nil {
+		t.Errorf("failed to generate signed and encrypted contract - %v", err)
+	}
+
+	assert.Contains(t, result, hpcrEncryptPrefix)
+	assert.Equal(t, inputSha256, simpleContractInputChecksum)
+}
+
+// Testcase to check if EncryptWrapperWithSigner() signs contract with signer and injects its public key
+func TestEncryptWrapperWithSigner(t *testing.T) {
+	contract, privateKey, _, _, _, err := common("TestEncryptWrapper")
+	if err != nil {
+		t.Errorf("failed to get contract, private key and public key - %v", err)
+	}
+
+	contractSigner, err := sign.NewPemSigner(privateKey)
+	if err != nil {
+		t.Fatalf("failed to create signer - %v", err)
+	}
+
+	result, err := EncryptWrapperWithSigner(contract, "", contractSigner, "", Options{Provider: prov.NativeProvider{}})
+	if err != nil {
+		t.Errorf("failed to sign and encrypt contract - %v", err)
+	}
+
+	assert.Contains(t, result, "envWorkloadSignature")
+}
+
+// Testcase to check if HpcrContractSignedEncryptedWithSigner() generates contract using signer
+func TestHpcrContractSignedEncryptedWithSigner(t *testing.T) {
+	contract, privateKey, _, _, _, err := common("TestHpcrContractSignedEncrypted")
+	if err != nil {
+		t.Errorf("failed to get contract and private key - %v", err)
+	}
+
+	contractSigner, err := sign.NewPemSigner(privateKey)
+	if err != nil {
+		t.Fatalf("failed to create signer - %v", err)
+	}
+
+	result, inputSha256, _, err := HpcrContractSignedEncryptedWithSigner(contract, "", contractSigner, Options{})
+	if err != nil {
+		t.Errorf("failed to generate signed and encrypted contract - %v", err)
+	}
+
+	assert.NotEmpty(t, result)
+	assert.Equal(t, inputSha256, simpleContractInputChecksum)
+}
+
+// Testcase to check if HpcrContractSignedEncryptedContractExpiryWithSigner() generates contract expiry contract using signer
+func TestHpcrContractSignedEncryptedContractExpiryWithSigner(t *testing.T) {
+	contract, privateKey, _, caCert, caKey, err := common("TestHpcrContractSignedEncryptedContractExpiryCsrParams")
+	if err != nil {
+		t.Errorf("failed to get contract, private key, CA certificate and CA key - %v", err)
+	}
+
+	contractSigner, err := sign.NewPemSigner(privateKey)
+	if err != nil {
+		t.Fatalf("failed to create signer - %v", err)
+	}
+
+	csrParams, err := json.Marshal(sampleCeCSRPems)
+	if err != nil {
+		t.Errorf("failed to unmarshal CSR parameters - %v", err)
+	}
+
+	result, inputSha256, _, err := HpcrContractSignedEncryptedContractExpiryWithSigner(contract, "", contractSigner, caCert, caKey, string(csrParams), "", sampleContractExpiryDays, Options{})
+	if err != nil {
+		t.Errorf("failed to generate signed and encrypted contract with contract expiry - %v", err)
+	}
+
+	assert.NotEmpty(t, result)
+	assert.Equal(t, inputSha256, simpleContractInputChecksum)
+}
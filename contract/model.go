@@ -0,0 +1,314 @@
+package contract
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+)
+
+const (
+	workloadType = "workload"
+	envType      = "env"
+)
+
+type (
+	// Contract - HPCR contract
+	Contract struct {
+		Workload *Workload `yaml:"workload,omitempty" json:"workload,omitempty"`
+		Env      *Env      `yaml:"env,omitempty" json:"env,omitempty"`
+		// AttestationPublicKey - public key to encrypt attestation records, top level key of contract
+		AttestationPublicKey string `yaml:"attestationPublicKey,omitempty" json:"attestationPublicKey,omitempty"`
+	}
+
+	// Workload - workload section of contract
+	Workload struct {
+		Type                   string                    `yaml:"type" json:"type"`
+		Auths                  map[string]Auth           `yaml:"auths,omitempty" json:"auths,omitempty"`
+		Compose                *Compose                  `yaml:"compose,omitempty" json:"compose,omitempty"`
+		Play                   *Play                     `yaml:"play,omitempty" json:"play,omitempty"`
+		Images                 *Images                   `yaml:"images,omitempty" json:"images,omitempty"`
+		Volumes                map[string]WorkloadVolume `yaml:"volumes,omitempty" json:"volumes,omitempty"`
+		Env                    map[string]string         `yaml:"env,omitempty" json:"env,omitempty"`
+		ConfidentialContainers map[string]interface{}    `yaml:"confidential-containers,omitempty" json:"confidential-containers,omitempty"`
+	}
+
+	// Auth - credentials of container registry
+	Auth struct {
+		Username string `yaml:"username" json:"username"`
+		Password string `yaml:"password" json:"password"`
+	}
+
+	// Compose - docker compose workload
+	Compose struct {
+		Archive string `yaml:"archive" json:"archive"`
+	}
+
+	// Play - podman play kube workload
+	Play struct {
+		Archive   string                   `yaml:"archive,omitempty" json:"archive,omitempty"`
+		Resources []map[string]interface{} `yaml:"resources,omitempty" json:"resources,omitempty"`
+		Templates []map[string]interface{} `yaml:"templates,omitempty" json:"templates,omitempty"`
+	}
+
+	// Images - image signature verification settings
+	Images struct {
+		Dct map[string]DctImage `yaml:"dct,omitempty" json:"dct,omitempty"`
+		Rhs map[string]RhsImage `yaml:"rhs,omitempty" json:"rhs,omitempty"`
+	}
+
+	// DctImage - Docker Content Trust settings of image
+	DctImage struct {
+		Notary    string `yaml:"notary" json:"notary"`
+		PublicKey string `yaml:"publicKey" json:"publicKey"`
+	}
+
+	// RhsImage - Red Hat simple signing settings of image
+	RhsImage struct {
+		PublicKey string `yaml:"publicKey" json:"publicKey"`
+	}
+
+	// WorkloadVolume - data volume settings from workload persona
+	WorkloadVolume struct {
+		Filesystem string `yaml:"filesystem,omitempty" json:"filesystem,omitempty"`
+		Mount      string `yaml:"mount,omitempty" json:"mount,omitempty"`
+		Seed       string `yaml:"seed,omitempty" json:"seed,omitempty"`
+	}
+
+	// Env - env section of contract
+	Env struct {
+		Type                   string                 `yaml:"type" json:"type"`
+		Logging                *Logging               `yaml:"logging,omitempty" json:"logging,omitempty"`
+		Volumes                map[string]EnvVolume   `yaml:"volumes,omitempty" json:"volumes,omitempty"`
+		Env                    map[string]string      `yaml:"env,omitempty" json:"env,omitempty"`
+		SigningKey             string                 `yaml:"signingKey,omitempty" json:"signingKey,omitempty"`
+		ConfidentialContainers map[string]interface{} `yaml:"confidential-containers,omitempty" json:"confidential-containers,omitempty"`
+	}
+
+	// Logging - logging settings
+	Logging struct {
+		LogDNA    *LogDNA    `yaml:"logDNA,omitempty" json:"logDNA,omitempty"`
+		Syslog    *Syslog    `yaml:"syslog,omitempty" json:"syslog,omitempty"`
+		LogRouter *LogRouter `yaml:"logRouter,omitempty" json:"logRouter,omitempty"`
+	}
+
+	// LogDNA - IBM Log Analysis settings
+	LogDNA struct {
+		Hostname     string   `yaml:"hostname" json:"hostname"`
+		IngestionKey string   `yaml:"ingestionKey" json:"ingestionKey"`
+		Port         int      `yaml:"port,omitempty" json:"port,omitempty"`
+		Tags         []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+	}
+
+	// Syslog - syslog server settings
+	Syslog struct {
+		Hostname string `yaml:"hostname" json:"hostname"`
+		Port     int    `yaml:"port,omitempty" json:"port,omitempty"`
+		Server   string `yaml:"server,omitempty" json:"server,omitempty"`
+		Cert     string `yaml:"cert,omitempty" json:"cert,omitempty"`
+		Key      string `yaml:"key,omitempty" json:"key,omitempty"`
+	}
+
+	// LogRouter - IBM Cloud Logs router settings
+	LogRouter struct {
+		Hostname  string `yaml:"hostname" json:"hostname"`
+		IamApiKey string `yaml:"iamApiKey" json:"iamApiKey"`
+		Port      int    `yaml:"port,omitempty" json:"port,omitempty"`
+	}
+
+	// EnvVolume - data volume settings from env persona
+	EnvVolume struct {
+		Seed string `yaml:"seed" json:"seed"`
+	}
+
+	// ContractBuilder - fluent builder of Contract
+	ContractBuilder struct {
+		contract Contract
+	}
+)
+
+// ParseContract - function to parse YAML or JSON contract, unknown keys are rejected
+func ParseContract(contract string) (*Contract, error) {
+	var result Contract
+
+	decoder := yaml.NewDecoder(bytes.NewReader([]byte(contract)))
+	decoder.KnownFields(true)
+
+	err := decoder.Decode(&result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse contract - %v", err)
+	}
+
+	return &result, nil
+}
+
+// ToYaml - function to convert contract to YAML
+func (c *Contract) ToYaml() (string, error) {
+	contractYaml, err := yaml.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal contract to YAML - %v", err)
+	}
+
+	return string(contractYaml), nil
+}
+
+// ToJson - function to convert contract to JSON
+func (c *Contract) ToJson() (string, error) {
+	contractJson, err := json.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal contract to JSON - %v", err)
+	}
+
+	return string(contractJson), nil
+}
+
+// Validate - function to validate contract against contract schema
+func (c *Contract) Validate() error {
+	contractYaml, err := c.ToYaml()
+	if err != nil {
+		return err
+	}
+
+	return gen.VerifyContractWithSchema(contractYaml)
+}
+
+// NewContractBuilder - function to create builder for contract with empty workload and env
+func NewContractBuilder() *ContractBuilder {
+	return &ContractBuilder{
+		contract: Contract{
+			Workload: &Workload{Type: workloadType},
+			Env:      &Env{Type: envType},
+		},
+	}
+}
+
+// WithComposeArchive - function to set base64 compose archive (eg: output of HpcrTgz)
+func (b *ContractBuilder) WithComposeArchive(archive string) *ContractBuilder {
+	b.contract.Workload.Compose = &Compose{Archive: archive}
+	return b
+}
+
+// WithPlay - function to set podman play workload
+func (b *ContractBuilder) WithPlay(play Play) *ContractBuilder {
+	b.contract.Workload.Play = &play
+	return b
+}
+
+// WithAuth - function to add registry credentials
+func (b *ContractBuilder) WithAuth(registry, username, password string) *ContractBuilder {
+	if b.contract.Workload.Auths == nil {
+		b.contract.Workload.Auths = map[string]Auth{}
+	}
+	b.contract.Workload.Auths[registry] = Auth{Username: username, Password: password}
+	return b
+}
+
+// WithAuths - function to add credentials of several registries (eg: output of HpcrRegistryAuths)
+func (b *ContractBuilder) WithAuths(auths map[string]Auth) *ContractBuilder {
+	for registry, auth := range auths {
+		b.WithAuth(registry, auth.Username, auth.Password)
+	}
+	return b
+}
+
+// WithImages - function to set image signature verification settings
+func (b *ContractBuilder) WithImages(images Images) *ContractBuilder {
+	b.contract.Workload.Images = &images
+	return b
+}
+
+// WithWorkloadVolume - function to add data volume settings of workload persona
+func (b *ContractBuilder) WithWorkloadVolume(name string, volume WorkloadVolume) *ContractBuilder {
+	if b.contract.Workload.Volumes == nil {
+		b.contract.Workload.Volumes = map[string]WorkloadVolume{}
+	}
+	b.contract.Workload.Volumes[name] = volume
+	return b
+}
+
+// WithWorkloadEnv - function to add environment variable to workload section
+func (b *ContractBuilder) WithWorkloadEnv(key, value string) *ContractBuilder {
+	if b.contract.Workload.Env == nil {
+		b.contract.Workload.Env = map[string]string{}
+	}
+	b.contract.Workload.Env[key] = value
+	return b
+}
+
+// WithLogging - function to set logging settings
+func (b *ContractBuilder) WithLogging(logging Logging) *ContractBuilder {
+	b.contract.Env.Logging = &logging
+	return b
+}
+
+// WithLogDNA - function to set IBM Log Analysis logging
+func (b *ContractBuilder) WithLogDNA(hostname, ingestionKey string) *ContractBuilder {
+	return b.WithLogging(Logging{LogDNA: &LogDNA{Hostname: hostname, IngestionKey: ingestionKey}})
+}
+
+// WithEnvVolume - function to add data volume settings of env persona
+func (b *ContractBuilder) WithEnvVolume(name string, volume EnvVolume) *ContractBuilder {
+	if b.contract.Env.Volumes == nil {
+		b.contract.Env.Volumes = map[string]EnvVolume{}
+	}
+	b.contract.Env.Volumes[name] = volume
+	return b
+}
+
+// WithEnv - function to add environment variable to env section
+func (b *ContractBuilder) WithEnv(key, value string) *ContractBuilder {
+	if b.contract.Env.Env == nil {
+		b.contract.Env.Env = map[string]string{}
+	}
+	b.contract.Env.Env[key] = value
+	return b
+}
+
+// WithAttestationPublicKey - function to set public key used to encrypt attestation records
+func (b *ContractBuilder) WithAttestationPublicKey(publicKey string) *ContractBuilder {
+	b.contract.AttestationPublicKey = publicKey
+	return b
+}
+
+// Build - function to return contract after validating it against contract schema
+func (b *ContractBuilder) Build() (*Contract, error) {
+	result := b.contract
+
+	err := result.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("schema verification failed - %v", err)
+	}
+
+	return &result, nil
+}
+
+// HpcrContractSignedEncryptedTyped - function to generate Signed and Encrypted contract from typed contract
+func HpcrContractSignedEncryptedTyped(contract *Contract, encryptionCertificate, privateKey string, opts Options) (string, string, string, error) {
+	if contract == nil {
+		return "", "", "", fmt.Errorf(emptyParameterErrStatement)
+	}
+
+	contractYaml, err := contract.ToYaml()
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return HpcrContractSignedEncryptedWithOptions(contractYaml, encryptionCertificate, privateKey, opts)
+}
+
+// HpcrContractSignedEncryptedContractExpiryTyped - function to generate sign with contract expiry enabled and encrypt typed contract
+func HpcrContractSignedEncryptedContractExpiryTyped(contract *Contract, encryptionCertificate, privateKey, cacert, caKey, csrDataStr, csrPemData string, expiryDays int) (string, string, string, error) {
+	if contract == nil {
+		return "", "", "", fmt.Errorf(emptyParameterErrStatement)
+	}
+
+	contractYaml, err := contract.ToYaml()
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return HpcrContractSignedEncryptedContractExpiry(contractYaml, encryptionCertificate, privateKey, cacert, caKey, csrDataStr, csrPemData, expiryDays)
+}
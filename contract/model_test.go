@@ -0,0 +1,216 @@
+package contract
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+	prov "github.com/Sashwat-K/lib-hpcr/common/provider"
+)
+
+const (
+	sampleLogDNAHostname     = "syslog-a.eu-de.logging.cloud.ibm.com"
+	sampleLogDNAIngestionKey = "ab00e3c09p1d4ff7fff9f04c12183413"
+	sampleComposeArchive     = "kdsbfoijdfojsnbo"
+)
+
+// Testcase to check if ParseContract() parses contract and rejects unknown keys
+func TestParseContract(t *testing.T) {
+	contract, err := gen.ReadDataFromFile(simpleContractPath)
+	if err != nil {
+		t.Errorf("failed to read contract - %v", err)
+	}
+
+	result, err := ParseContract(contract)
+	if err != nil {
+		t.Errorf("failed to parse contract - %v", err)
+	}
+
+	assert.Equal(t, result.Workload.Compose.Archive, sampleComposeArchive)
+	assert.Equal(t, result.Env.Logging.LogDNA.Hostname, sampleLogDNAHostname)
+
+	_, err = ParseContract("env:\n  type: env\n  loging: {}\n")
+	assert.Error(t, err)
+}
+
+// Testcase to check if ToYaml() generates contract which is parsed back to same contract
+func TestToYaml(t *testing.T) {
+	contract, err := NewContractBuilder().WithComposeArchive(sampleComposeArchive).WithLogDNA(sampleLogDNAHostname, sampleLogDNAIngestionKey).Build()
+	if err != nil {
+		t.Errorf("failed to build contract - %v", err)
+	}
+
+	result, err := contract.ToYaml()
+	if err != nil {
+		t.Errorf("failed to convert contract to YAML - %v", err)
+	}
+
+	parsed, err := ParseContract(result)
+	if err != nil {
+		t.Errorf("failed to parse contract - %v", err)
+	}
+
+	assert.Equal(t, contract, parsed)
+}
+
+// Testcase to check if attestationPublicKey at top level of contract is parsed, built and signed as in schema
+func TestAttestationPublicKey(t *testing.T) {
+	publicKey, err := gen.ReadDataFromFile(samplePublicKeyPath)
+	if err != nil {
+		t.Errorf("failed to read public key - %v", err)
+	}
+
+	contract, err := NewContractBuilder().
+		WithComposeArchive(sampleComposeArchive).
+		WithLogDNA(sampleLogDNAHostname, sampleLogDNAIngestionKey).
+		WithAttestationPublicKey(publicKey).
+		Build()
+	if err != nil {
+		t.Errorf("failed to build contract - %v", err)
+	}
+
+	assert.Equal(t, contract.AttestationPublicKey, publicKey)
+
+	result, err := contract.ToYaml()
+	if err != nil {
+		t.Errorf("failed to convert contract to YAML - %v", err)
+	}
+
+	assert.NoError(t, gen.VerifyContractWithSchema(result))
+
+	parsed, err := ParseContract(result)
+	if err != nil {
+		t.Errorf("failed to parse contract - %v", err)
+	}
+
+	assert.Equal(t, contract, parsed)
+
+	_, privateKey, _, _, _, err := common("TestHpcrContractSignedEncrypted")
+	if err != nil {
+		t.Errorf("failed to get private key - %v", err)
+	}
+
+	finalContract, _, _, err := HpcrContractSignedEncryptedTyped(contract, "", privateKey, Options{Provider: prov.NativeProvider{}})
+	if err != nil {
+		t.Errorf("failed to generate signed and encrypted contract - %v", err)
+	}
+
+	var finalContractMap map[string]string
+	err = yaml.Unmarshal([]byte(finalContract), &finalContractMap)
+	if err != nil {
+		t.Errorf("failed to unmarshal contract - %v", err)
+	}
+
+	assert.Equal(t, finalContractMap["attestationPublicKey"], publicKey)
+}
+
+// Testcase to check if ToJson() generates JSON contract
+func TestToJson(t *testing.T) {
+	contract := &Contract{Env: &Env{Type: envType, SigningKey: "key"}}
+
+	result, err := contract.ToJson()
+	if err != nil {
+		t.Errorf("failed to convert contract to JSON - %v", err)
+	}
+
+	assert.Equal(t, result, `{"env":{"type":"env","signingKey":"key"}}`)
+}
+
+// Testcase to check if Validate() validates contract against schema
+func TestValidate(t *testing.T) {
+	contract := &Contract{Env: &Env{Type: envType}}
+
+	assert.Error(t, contract.Validate())
+
+	contract.Env.Logging = &Logging{LogDNA: &LogDNA{Hostname: sampleLogDNAHostname, IngestionKey: sampleLogDNAIngestionKey}}
+
+	assert.NoError(t, contract.Validate())
+}
+
+// Testcase to check if ContractBuilder builds contract with all given values
+func TestContractBuilder(t *testing.T) {
+	contract, err := NewContractBuilder().
+		WithComposeArchive(sampleComposeArchive).
+		WithAuth("us.icr.io", "iamapikey", "password").
+		WithAuths(map[string]Auth{"docker.io": {Username: "user", Password: "token"}}).
+		WithWorkloadVolume("test", WorkloadVolume{Filesystem: "ext4", Mount: "/mnt/data", Seed: "workload"}).
+		WithWorkloadEnv("KEY", "workload").
+		WithLogDNA(sampleLogDNAHostname, sampleLogDNAIngestionKey).
+		WithEnvVolume("test", EnvVolume{Seed: "env"}).
+		WithEnv("KEY", "env").
+		Build()
+	if err != nil {
+		t.Errorf("failed to build contract - %v", err)
+	}
+
+	assert.Equal(t, contract.Workload.Auths["us.icr.io"].Username, "iamapikey")
+	assert.Equal(t, contract.Workload.Auths["docker.io"].Password, "token")
+	assert.Equal(t, contract.Workload.Volumes["test"].Seed, "workload")
+	assert.Equal(t, contract.Env.Volumes["test"].Seed, "env")
+	assert.Equal(t, contract.Env.Env["KEY"], "env")
+
+	_, err = NewContractBuilder().WithComposeArchive(sampleComposeArchive).Build()
+	assert.Error(t, err)
+}
+
+// Testcase to check if HpcrContractSignedEncryptedTyped() generates signed and encrypted contract from typed contract
+func TestHpcrContractSignedEncryptedTyped(t *testing.T) {
+	_, privateKey, _, _, _, err := common("TestHpcrContractSignedEncrypted")
+	if err != nil {
+		t.Errorf("failed to get private key - %v", err)
+	}
+
+	contract, err := NewContractBuilder().WithComposeArchive(sampleComposeArchive).WithLogDNA(sampleLogDNAHostname, sampleLogDNAIngestionKey).Build()
+	if err != nil {
+		t.Errorf("failed to build contract - %v", err)
+	}
+
+	result, _, _, err := HpcrContractSignedEncryptedTyped(contract, "", privateKey, Options{Provider: prov.NativeProvider{}})
+	if err != nil {
+		t.Errorf("failed to generate signed and encrypted contract - %v", err)
+	}
+
+	assert.Contains(t, result, hpcrEncryptPrefix)
+
+	_, _, _, err = HpcrContractSignedEncryptedTyped(nil, "", privateKey, Options{})
+	assert.Error(t, err)
+}
+
+// Testcase to check if HpcrContractSignedEncryptedContractExpiryTyped() generates contract expiry contract from typed contract
+func TestHpcrContractSignedEncryptedContractExpiryTyped(t *testing.T) {
+	_, privateKey, _, caCert, caKey, err := common("TestHpcrContractSignedEncryptedContractExpiryCsrParams")
+	if err != nil {
+		t.Errorf("failed to get private key, CA certificate and CA key - %v", err)
+	}
+
+	csrParams, err := json.Marshal(sampleCeCSRPems)
+	if err != nil {
+		t.Errorf("failed to unmarshal CSR parameters - %v", err)
+	}
+
+	contract, err := NewContractBuilder().WithComposeArchive(sampleComposeArchive).WithLogDNA(sampleLogDNAHostname, sampleLogDNAIngestionKey).Build()
+	if err != nil {
+		t.Errorf("failed to build contract - %v", err)
+	}
+
+	result, _, _, err := HpcrContractSignedEncryptedContractExpiryTyped(contract, "", privateKey, caCert, caKey, string(csrParams), "", sampleContractExpiryDays)
+	if err != nil {
+		t.Errorf("failed to generate signed and encrypted contract with contract expiry - %v", err)
+	}
+
+	assert.NotEmpty(t, result)
+}
+
+// Testcase to check if EncryptWrapper() returns error instead of panicking when section is not a map
+func TestEncryptWrapperInvalidSection(t *testing.T) {
+	_, privateKey, publicKey, _, _, err := common("TestEncryptWrapper")
+	if err != nil {
+		t.Errorf("failed to get private key and public key - %v", err)
+	}
+
+	_, err = EncryptWrapperWithOptions("workload: abc\nenv: def\n", "", privateKey, publicKey, Options{Provider: fakeProvider{}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,169 @@
+package contract
+
+import (
+	"encoding/json"
+	"fmt"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+	prov "github.com/Sashwat-K/lib-hpcr/common/provider"
+)
+
+const (
+	personaArtifactVersion = "1"
+)
+
+// PersonaArtifact - encrypted section produced by workload or env owner, exchanged as JSON between personas
+type PersonaArtifact struct {
+	// Version - version of artifact format
+	Version string `json:"version"`
+	// Section - workload or env
+	Section string `json:"section"`
+	// EncryptedSection - hyper-protect-basic encrypted section
+	EncryptedSection string `json:"encryptedSection"`
+	// EncryptionCertificateFingerprint - hex SHA256 of DER encryption certificate used to encrypt section
+	EncryptionCertificateFingerprint string `json:"encryptionCertificateFingerprint"`
+	// SigningKeyFingerprint - hex SHA256 of DER public key injected as signingKey (env only)
+	SigningKeyFingerprint string `json:"signingKeyFingerprint,omitempty"`
+	// InputSha256 - hex SHA256 of plain section
+	InputSha256 string `json:"inputSha256"`
+}
+
+// HpcrWorkloadArtifact - function for workload owner to encrypt workload section and generate artifact for the signer
+func HpcrWorkloadArtifact(workload, encryptionCertificate string, opts Options) (string, error) {
+	return personaArtifact(workloadKey, workload, encryptionCertificate, "", opts)
+}
+
+// HpcrEnvArtifact - function for env owner to encrypt env section with signingKey and generate artifact for the signer
+// publicKey is the PEM public key of the signer (or signing certificate for contract expiry)
+func HpcrEnvArtifact(env, encryptionCertificate, publicKey string, opts Options) (string, error) {
+	if gen.CheckIfEmpty(publicKey) {
+		return "", fmt.Errorf(emptyParameterErrStatement)
+	}
+
+	return personaArtifact(envKey, env, encryptionCertificate, publicKey, opts)
+}
+
+// HpcrContractFromArtifacts - function for signer to check workload and env artifacts and sign final contract
+func HpcrContractFromArtifacts(workloadArtifact, envArtifact, privateKey string, opts Options) (string, string, string, error) {
+	if gen.CheckIfEmpty(workloadArtifact, envArtifact, privateKey) {
+		return "", "", "", fmt.Errorf(emptyParameterErrStatement)
+	}
+
+	workload, err := ParsePersonaArtifact(workloadArtifact, workloadKey)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	env, err := ParsePersonaArtifact(envArtifact, envKey)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	if workload.EncryptionCertificateFingerprint != env.EncryptionCertificateFingerprint {
+		return "", "", "", fmt.Errorf("workload and env are encrypted with different encryption certificates")
+	}
+
+	cryptoProvider := prov.GetProvider(opts.Provider)
+
+	publicKey, err := cryptoProvider.GeneratePublicKey(privateKey)
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to generate public key - %v", err)
+	}
+
+	signingKeyFingerprint, err := gen.PublicKeyFingerprint(publicKey)
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to get fingerprint of public key - %v", err)
+	}
+
+	if env.SigningKeyFingerprint != signingKeyFingerprint {
+		return "", "", "", fmt.Errorf("signingKey of env doesn't match private key")
+	}
+
+	finalContract, err := signSections(Section{Mode: SectionEncrypted, Data: workload.EncryptedSection}, Section{Mode: SectionEncrypted, Data: env.EncryptedSection}, "", "", func(workloadValue, envValue string) (string, error) {
+		return cryptoProvider.SignContract(workloadValue, envValue, privateKey)
+	}, opts)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return finalContract, gen.GenerateSha256(workloadArtifact + envArtifact), gen.GenerateSha256(finalContract), nil
+}
+
+// ParsePersonaArtifact - function to parse artifact and check that it contains the expected section
+func ParsePersonaArtifact(artifact, section string) (PersonaArtifact, error) {
+	var personaArtifact PersonaArtifact
+
+	err := json.Unmarshal([]byte(artifact), &personaArtifact)
+	if err != nil {
+		return PersonaArtifact{}, fmt.Errorf("failed to unmarshal %s artifact - %v", section, err)
+	}
+
+	if personaArtifact.Version != personaArtifactVersion {
+		return PersonaArtifact{}, fmt.Errorf("unsupported %s artifact version - %s", section, personaArtifact.Version)
+	}
+
+	if personaArtifact.Section != section {
+		return PersonaArtifact{}, fmt.Errorf("expected %s artifact, got %s", section, personaArtifact.Section)
+	}
+
+	if gen.CheckIfEmpty(personaArtifact.EncryptedSection, personaArtifact.EncryptionCertificateFingerprint) {
+		return PersonaArtifact{}, fmt.Errorf("%s artifact is incomplete", section)
+	}
+
+	if section == envKey && personaArtifact.SigningKeyFingerprint == "" {
+		return PersonaArtifact{}, fmt.Errorf("env artifact doesn't have signingKey fingerprint")
+	}
+
+	_, err = prepareSection(section, Section{Mode: SectionEncrypted, Data: personaArtifact.EncryptedSection}, "", "", Options{})
+	if err != nil {
+		return PersonaArtifact{}, err
+	}
+
+	return personaArtifact, nil
+}
+
+// personaArtifact - function to encrypt section and serialize it with its metadata as JSON
+func personaArtifact(section, data, encryptionCertificate, publicKey string, opts Options) (string, error) {
+	if gen.CheckIfEmpty(data) {
+		return "", fmt.Errorf(emptyParameterErrStatement)
+	}
+
+	encryptCertificate := gen.FetchEncryptionCertificate(encryptionCertificate)
+
+	certificateFingerprint, err := gen.CertificateFingerprint(encryptCertificate)
+	if err != nil {
+		return "", fmt.Errorf("failed to get fingerprint of encryption certificate - %v", err)
+	}
+
+	var signingKeyFingerprint string
+	if publicKey != "" {
+		signingKeyPem, err := gen.DecodeSigningKey(publicKey)
+		if err != nil {
+			return "", fmt.Errorf("failed to decode public key - %v", err)
+		}
+
+		signingKeyFingerprint, err = gen.PublicKeyFingerprint(signingKeyPem)
+		if err != nil {
+			return "", fmt.Errorf("failed to get fingerprint of public key - %v", err)
+		}
+	}
+
+	encryptedSection, inputSha256, _, err := HpcrSectionEncrypted(section, data, encryptCertificate, publicKey, opts)
+	if err != nil {
+		return "", err
+	}
+
+	artifact, err := json.MarshalIndent(PersonaArtifact{
+		Version:                          personaArtifactVersion,
+		Section:                          section,
+		EncryptedSection:                 encryptedSection,
+		EncryptionCertificateFingerprint: certificateFingerprint,
+		SigningKeyFingerprint:            signingKeyFingerprint,
+		InputSha256:                      inputSha256,
+	}, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal %s artifact - %v", section, err)
+	}
+
+	return string(artifact) + "\n", nil
+}
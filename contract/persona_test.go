@@ -0,0 +1,102 @@
+package contract
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cert "github.com/Sashwat-K/lib-hpcr/certificate"
+	prov "github.com/Sashwat-K/lib-hpcr/common/provider"
+)
+
+// Testcase to check if HpcrWorkloadArtifact() generates workload artifact
+func TestHpcrWorkloadArtifact(t *testing.T) {
+	encryptionCertificate, _, _ := sectionTestData(t)
+
+	result, err := HpcrWorkloadArtifact(sampleWorkloadSection, encryptionCertificate, Options{Provider: prov.NativeProvider{}})
+	if err != nil {
+		t.Errorf("failed to generate workload artifact - %v", err)
+	}
+
+	artifact, err := ParsePersonaArtifact(result, "workload")
+	if err != nil {
+		t.Errorf("failed to parse workload artifact - %v", err)
+	}
+
+	assert.Contains(t, artifact.EncryptedSection, hpcrEncryptPrefix)
+	assert.Empty(t, artifact.SigningKeyFingerprint)
+}
+
+// Testcase to check if HpcrEnvArtifact() generates env artifact with signingKey fingerprint
+func TestHpcrEnvArtifact(t *testing.T) {
+	encryptionCertificate, _, publicKey := sectionTestData(t)
+
+	result, err := HpcrEnvArtifact(sampleEnvSection, encryptionCertificate, publicKey, Options{Provider: prov.NativeProvider{}})
+	if err != nil {
+		t.Errorf("failed to generate env artifact - %v", err)
+	}
+
+	artifact, err := ParsePersonaArtifact(result, "env")
+	if err != nil {
+		t.Errorf("failed to parse env artifact - %v", err)
+	}
+
+	assert.NotEmpty(t, artifact.SigningKeyFingerprint)
+
+	_, err = ParsePersonaArtifact(result, "workload")
+	assert.Error(t, err)
+
+	_, err = HpcrEnvArtifact(sampleEnvSection, encryptionCertificate, "", Options{})
+	assert.Error(t, err)
+}
+
+// Testcase to check if HpcrContractFromArtifacts() signs contract from consistent artifacts and rejects inconsistent ones
+func TestHpcrContractFromArtifacts(t *testing.T) {
+	encryptionCertificate, privateKey, publicKey := sectionTestData(t)
+	opts := Options{Provider: prov.NativeProvider{}}
+
+	workloadArtifact, err := HpcrWorkloadArtifact(sampleWorkloadSection, encryptionCertificate, opts)
+	if err != nil {
+		t.Errorf("failed to generate workload artifact - %v", err)
+	}
+
+	envArtifact, err := HpcrEnvArtifact(sampleEnvSection, encryptionCertificate, publicKey, opts)
+	if err != nil {
+		t.Errorf("failed to generate env artifact - %v", err)
+	}
+
+	result, _, _, err := HpcrContractFromArtifacts(workloadArtifact, envArtifact, privateKey, opts)
+	if err != nil {
+		t.Errorf("failed to generate contract from artifacts - %v", err)
+	}
+
+	assert.NoError(t, HpcrVerifyContractSignature(result, publicKey, ""))
+
+	otherCertificate, otherPrivateKey, err := cert.HpcrGenerateTestEncryptionCertificate(1)
+	if err != nil {
+		t.Errorf("failed to generate test encryption certificate - %v", err)
+	}
+
+	otherWorkloadArtifact, err := HpcrWorkloadArtifact(sampleWorkloadSection, otherCertificate, opts)
+	if err != nil {
+		t.Errorf("failed to generate workload artifact - %v", err)
+	}
+
+	_, _, _, err = HpcrContractFromArtifacts(otherWorkloadArtifact, envArtifact, privateKey, opts)
+	assert.ErrorContains(t, err, "different encryption certificates")
+
+	_, _, _, err = HpcrContractFromArtifacts(workloadArtifact, envArtifact, otherPrivateKey, opts)
+	assert.ErrorContains(t, err, "signingKey of env doesn't match")
+}
+
+// Testcase to check if ParsePersonaArtifact() rejects invalid artifacts
+func TestParsePersonaArtifact(t *testing.T) {
+	_, err := ParsePersonaArtifact("{", "workload")
+	assert.Error(t, err)
+
+	_, err = ParsePersonaArtifact(`{"version":"2","section":"workload"}`, "workload")
+	assert.Error(t, err)
+
+	_, err = ParsePersonaArtifact(`{"version":"1","section":"workload","encryptedSection":"abc","encryptionCertificateFingerprint":"abc"}`, "workload")
+	assert.Error(t, err)
+}
@@ -0,0 +1,187 @@
+package contract
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Sashwat-K/lib-hpcr/common/envelope"
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+	prov "github.com/Sashwat-K/lib-hpcr/common/provider"
+	sign "github.com/Sashwat-K/lib-hpcr/common/signer"
+)
+
+const (
+	workloadKey = "workload"
+	envKey      = "env"
+)
+
+// SectionMode - representation of workload or env section in final contract
+// Plain sections are not supported, as the bytes HPCR verifies the signature of a plain section against are not known
+type SectionMode string
+
+const (
+	// SectionEncrypt - section is plain YAML and is encrypted by the library
+	SectionEncrypt SectionMode = "encrypt"
+	// SectionEncrypted - section is already encrypted hyper-protect-basic string
+	SectionEncrypted SectionMode = "encrypted"
+)
+
+// Section - workload or env section of contract
+type Section struct {
+	// Mode - how the section is added to contract
+	Mode SectionMode
+	// Data - YAML of section (without workload/env key) for encrypt mode, hyper-protect-basic string for encrypted mode
+	Data string
+}
+
+// HpcrSectionEncrypted - function to encrypt only workload or env section, so that each persona can encrypt its own section
+// If publicKey is given, it is injected as signingKey to env before encryption, it can't be given for workload
+func HpcrSectionEncrypted(key, section, encryptionCertificate, publicKey string, opts Options) (string, string, string, error) {
+	if gen.CheckIfEmpty(key, section) {
+		return "", "", "", fmt.Errorf(emptyParameterErrStatement)
+	}
+
+	if key == workloadKey && publicKey != "" {
+		return "", "", "", fmt.Errorf("public key can only be injected as signingKey to %s", envKey)
+	}
+
+	encryptedSection, err := prepareSection(key, Section{Mode: SectionEncrypt, Data: section}, encryptionCertificate, publicKey, opts)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return encryptedSection, gen.GenerateSha256(section), gen.GenerateSha256(encryptedSection), nil
+}
+
+// HpcrContractSignedEncryptedSections - function to generate signed contract where workload and env are each encrypted, already encrypted or plain
+// signingKey can't be injected to an already encrypted env, it must have been added by HpcrSectionEncrypted() with the same public key
+func HpcrContractSignedEncryptedSections(workload, env Section, encryptionCertificate, privateKey string, opts Options) (string, string, string, error) {
+	if gen.CheckIfEmpty(workload.Data, env.Data, privateKey) {
+		return "", "", "", fmt.Errorf(emptyParameterErrStatement)
+	}
+
+	cryptoProvider := prov.GetProvider(opts.Provider)
+
+	publicKey, err := cryptoProvider.GeneratePublicKey(privateKey)
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to generate public key - %v", err)
+	}
+
+	finalContract, err := signSections(workload, env, encryptionCertificate, publicKey, func(workloadValue, envValue string) (string, error) {
+		return cryptoProvider.SignContract(workloadValue, envValue, privateKey)
+	}, opts)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return finalContract, gen.GenerateSha256(workload.Data + env.Data), gen.GenerateSha256(finalContract), nil
+}
+
+// HpcrContractSignedEncryptedSectionsWithSigner - function to generate contract from sections where signing key is held by signer
+func HpcrContractSignedEncryptedSectionsWithSigner(workload, env Section, encryptionCertificate string, contractSigner sign.Signer, opts Options) (string, string, string, error) {
+	if gen.CheckIfEmpty(workload.Data, env.Data) || contractSigner == nil {
+		return "", "", "", fmt.Errorf(emptyParameterErrStatement)
+	}
+
+	publicKey, err := sign.PublicKeyPem(contractSigner)
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to get public key of signer - %v", err)
+	}
+
+	finalContract, err := signSections(workload, env, encryptionCertificate, publicKey, func(workloadValue, envValue string) (string, error) {
+		return sign.SignContract(workloadValue, envValue, contractSigner)
+	}, opts)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return finalContract, gen.GenerateSha256(workload.Data + env.Data), gen.GenerateSha256(finalContract), nil
+}
+
+// signSections - function to prepare sections and sign their hyper-protect-basic strings as they are in final contract
+func signSections(workload, env Section, encryptionCertificate, publicKey string, signContract func(workloadValue, envValue string) (string, error), opts Options) (string, error) {
+	workloadSection, err := prepareSection(workloadKey, workload, encryptionCertificate, "", opts)
+	if err != nil {
+		return "", err
+	}
+
+	envSection, err := prepareSection(envKey, env, encryptionCertificate, publicKey, opts)
+	if err != nil {
+		return "", err
+	}
+
+	workloadEnvSignature, err := signContract(workloadSection, envSection)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign contract - %v", err)
+	}
+
+	contractMap := map[string]interface{}{
+		workloadKey:            workloadSection,
+		envKey:                 envSection,
+		"envWorkloadSignature": workloadEnvSignature,
+	}
+
+	finalContract, err := gen.MapToYaml(contractMap)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate final contract - %v", err)
+	}
+
+	return finalContract, nil
+}
+
+// prepareSection - function to return hyper-protect-basic string of section as it goes to final contract
+func prepareSection(key string, section Section, encryptionCertificate, publicKey string, opts Options) (string, error) {
+	if key != workloadKey && key != envKey {
+		return "", fmt.Errorf("section must be %s or %s", workloadKey, envKey)
+	}
+
+	if section.Mode == SectionEncrypted {
+		encryptedSection, err := envelope.Parse(section.Data)
+		if err != nil {
+			return "", fmt.Errorf("%s is not a valid encrypted section - %v", key, err)
+		}
+
+		return encryptedSection.String(), nil
+	}
+
+	if section.Mode != SectionEncrypt {
+		return "", fmt.Errorf("unsupported mode of %s - %s", key, section.Mode)
+	}
+
+	var sectionMap map[string]interface{}
+	err := yaml.Unmarshal([]byte(section.Data), &sectionMap)
+	if err != nil {
+		return "", fmt.Errorf("failed to unmarshal %s - %v", key, err)
+	}
+
+	if sectionMap == nil {
+		return "", fmt.Errorf("%s is empty or not a mapping", key)
+	}
+
+	if key == envKey && publicKey != "" {
+		sectionMap["signingKey"] = gen.EncodeToBase64(publicKey)
+	}
+
+	sectionContract, err := gen.MapToYaml(map[string]interface{}{key: sectionMap})
+	if err != nil {
+		return "", fmt.Errorf("failed to convert MAP to YAML - %v", err)
+	}
+
+	err = gen.VerifyContractWithSchema(sectionContract)
+	if err != nil {
+		return "", fmt.Errorf("schema verification of %s failed - %v", key, err)
+	}
+
+	sectionData, err := gen.MapToYaml(sectionMap)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert MAP to YAML - %v", err)
+	}
+
+	encryptedSection, err := EncrypterWithOptions(sectionData, gen.FetchEncryptionCertificate(encryptionCertificate), opts)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt %s - %v", key, err)
+	}
+
+	return encryptedSection, nil
+}
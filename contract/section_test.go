@@ -0,0 +1,175 @@
+package contract
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+	prov "github.com/Sashwat-K/lib-hpcr/common/provider"
+	sign "github.com/Sashwat-K/lib-hpcr/common/signer"
+)
+
+const (
+	sampleWorkloadSection = "type: workload\ncompose:\n  archive: kdsbfoijdfojsnbo\n"
+	sampleEnvSection      = "type: env\nlogging:\n  logDNA:\n    hostname: syslog-a.eu-de.logging.cloud.ibm.com\n    ingestionKey: ab00e3c09p1d4ff7fff9f04c12183413\n"
+)
+
+// sectionTestData - function to read encryption certificate, private key and public key for section tests
+func sectionTestData(t *testing.T) (string, string, string) {
+	encryptionCertificate, err := gen.ReadDataFromFile(samplePublicKeyCertPath)
+	if err != nil {
+		t.Fatalf("failed to read encryption certificate - %v", err)
+	}
+
+	privateKey, err := gen.ReadDataFromFile(samplePrivateKeyPath)
+	if err != nil {
+		t.Fatalf("failed to read private key - %v", err)
+	}
+
+	publicKey, err := gen.ReadDataFromFile(samplePublicKeyPath)
+	if err != nil {
+		t.Fatalf("failed to read public key - %v", err)
+	}
+
+	return encryptionCertificate, privateKey, publicKey
+}
+
+// Testcase to check if HpcrSectionEncrypted() encrypts env and injects signingKey
+func TestHpcrSectionEncrypted(t *testing.T) {
+	encryptionCertificate, privateKey, publicKey := sectionTestData(t)
+	nativeProvider := prov.NativeProvider{}
+
+	result, inputSha256, _, err := HpcrSectionEncrypted("env", sampleEnvSection, encryptionCertificate, publicKey, Options{Provider: nativeProvider})
+	if err != nil {
+		t.Errorf("failed to encrypt env - %v", err)
+	}
+
+	assert.Contains(t, result, hpcrEncryptPrefix)
+	assert.Equal(t, inputSha256, gen.GenerateSha256(sampleEnvSection))
+
+	parts := strings.Split(result, ".")
+	password, err := nativeProvider.DecryptPassword(parts[1], privateKey)
+	if err != nil {
+		t.Errorf("failed to decrypt password - %v", err)
+	}
+
+	env, err := nativeProvider.DecryptWorkload(password, parts[2])
+	if err != nil {
+		t.Errorf("failed to decrypt env - %v", err)
+	}
+
+	assert.Contains(t, env, "signingKey: "+gen.EncodeToBase64(publicKey))
+
+	_, _, _, err = HpcrSectionEncrypted("volumes", sampleEnvSection, encryptionCertificate, "", Options{Provider: nativeProvider})
+	assert.Error(t, err)
+
+	_, _, _, err = HpcrSectionEncrypted("workload", sampleWorkloadSection, encryptionCertificate, publicKey, Options{Provider: nativeProvider})
+	assert.ErrorContains(t, err, "only be injected as signingKey to env")
+}
+
+// Testcase to check if HpcrSectionEncrypted() and HpcrEnvArtifact() return error for empty, comment only and null sections
+func TestHpcrSectionEncryptedEmpty(t *testing.T) {
+	encryptionCertificate, _, publicKey := sectionTestData(t)
+	opts := Options{Provider: prov.NativeProvider{}}
+
+	for _, section := range []string{" \n", "# only a comment", "~", "null"} {
+		_, _, _, err := HpcrSectionEncrypted("env", section, encryptionCertificate, publicKey, opts)
+		assert.Error(t, err, section)
+
+		_, err = HpcrEnvArtifact(section, encryptionCertificate, publicKey, opts)
+		assert.Error(t, err, section)
+	}
+}
+
+// Testcase to check if HpcrContractSignedEncryptedSections() signs contract with pre-encrypted workload and env encrypted by the library
+func TestHpcrContractSignedEncryptedSections(t *testing.T) {
+	encryptionCertificate, privateKey, publicKey := sectionTestData(t)
+	nativeProvider := prov.NativeProvider{}
+	opts := Options{Provider: nativeProvider}
+
+	encryptedWorkload, _, _, err := HpcrSectionEncrypted("workload", sampleWorkloadSection, encryptionCertificate, "", opts)
+	if err != nil {
+		t.Errorf("failed to encrypt workload - %v", err)
+	}
+
+	result, inputSha256, _, err := HpcrContractSignedEncryptedSections(Section{Mode: SectionEncrypted, Data: encryptedWorkload}, Section{Mode: SectionEncrypt, Data: sampleEnvSection}, encryptionCertificate, privateKey, opts)
+	if err != nil {
+		t.Errorf("failed to generate contract - %v", err)
+	}
+
+	assert.Equal(t, inputSha256, gen.GenerateSha256(encryptedWorkload+sampleEnvSection))
+	assert.NoError(t, HpcrVerifyContractSignature(result, publicKey, ""))
+
+	var contractMap map[string]string
+	err = yaml.Unmarshal([]byte(result), &contractMap)
+	if err != nil {
+		t.Errorf("failed to unmarshal contract - %v", err)
+	}
+
+	assert.Equal(t, contractMap["workload"], encryptedWorkload)
+
+	parts := strings.Split(contractMap["env"], ".")
+	password, err := nativeProvider.DecryptPassword(parts[1], privateKey)
+	if err != nil {
+		t.Errorf("failed to decrypt password - %v", err)
+	}
+
+	env, err := nativeProvider.DecryptWorkload(password, parts[2])
+	if err != nil {
+		t.Errorf("failed to decrypt env - %v", err)
+	}
+
+	assert.Contains(t, env, "signingKey: "+gen.EncodeToBase64(publicKey))
+
+	// signature covers hyper-protect-basic strings of workload and env as they are in contract
+	block, _ := pem.Decode([]byte(publicKey))
+	rsaPublicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		t.Fatalf("failed to parse public key - %v", err)
+	}
+
+	signature, err := gen.DecodeBase64String(contractMap["envWorkloadSignature"])
+	if err != nil {
+		t.Errorf("failed to decode signature - %v", err)
+	}
+
+	digest := sha256.Sum256([]byte(contractMap["workload"] + contractMap["env"]))
+	assert.NoError(t, rsa.VerifyPKCS1v15(rsaPublicKey.(*rsa.PublicKey), crypto.SHA256, digest[:], []byte(signature)))
+
+	_, _, _, err = HpcrContractSignedEncryptedSections(Section{Mode: SectionEncrypted, Data: sampleWorkloadSection}, Section{Mode: SectionEncrypt, Data: sampleEnvSection}, encryptionCertificate, privateKey, opts)
+	assert.Error(t, err)
+
+	_, _, _, err = HpcrContractSignedEncryptedSections(Section{Mode: SectionEncrypted, Data: encryptedWorkload}, Section{Mode: "plain", Data: sampleEnvSection}, encryptionCertificate, privateKey, opts)
+	assert.ErrorContains(t, err, "unsupported mode of env - plain")
+}
+
+// Testcase to check if HpcrContractSignedEncryptedSectionsWithSigner() signs contract with signer
+func TestHpcrContractSignedEncryptedSectionsWithSigner(t *testing.T) {
+	encryptionCertificate, privateKey, publicKey := sectionTestData(t)
+	opts := Options{Provider: prov.NativeProvider{}}
+
+	contractSigner, err := sign.NewPemSigner(privateKey)
+	if err != nil {
+		t.Fatalf("failed to create signer - %v", err)
+	}
+
+	encryptedEnv, _, _, err := HpcrSectionEncrypted("env", sampleEnvSection, encryptionCertificate, publicKey, opts)
+	if err != nil {
+		t.Errorf("failed to encrypt env - %v", err)
+	}
+
+	result, _, _, err := HpcrContractSignedEncryptedSectionsWithSigner(Section{Mode: SectionEncrypt, Data: sampleWorkloadSection}, Section{Mode: SectionEncrypted, Data: encryptedEnv}, encryptionCertificate, contractSigner, opts)
+	if err != nil {
+		t.Errorf("failed to generate contract - %v", err)
+	}
+
+	assert.NoError(t, HpcrVerifyContractSignature(result, publicKey, ""))
+}
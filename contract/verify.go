@@ -0,0 +1,124 @@
+package contract
+
+import (
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+	sign "github.com/Sashwat-K/lib-hpcr/common/signer"
+)
+
+// HpcrVerifyContractSignature - function to verify envWorkloadSignature of final contract with public key or signing certificate
+// For signing certificate (contract expiry), the validity window is checked and, if caCert is given, the chain to the CA
+func HpcrVerifyContractSignature(contract, signingKey, caCert string) error {
+	if gen.CheckIfEmpty(contract, signingKey) {
+		return fmt.Errorf(emptyParameterErrStatement)
+	}
+
+	workload, env, workloadEnvSignature, err := signedSections(contract)
+	if err != nil {
+		return err
+	}
+
+	signingKeyPem, err := gen.DecodeSigningKey(signingKey)
+	if err != nil {
+		return fmt.Errorf("failed to decode signing key - %v", err)
+	}
+
+	certificates, err := gen.ParseCertificates(signingKeyPem)
+	if err == nil {
+		err = verifySigningCertificate(certificates[0], caCert, time.Now())
+		if err != nil {
+			return err
+		}
+	} else if caCert != "" {
+		return fmt.Errorf("CA certificate is given but signing key is not a certificate")
+	}
+
+	err = sign.VerifyContract(workload, env, workloadEnvSignature, signingKeyPem)
+	if err != nil {
+		return fmt.Errorf("failed to verify envWorkloadSignature - %v", err)
+	}
+
+	return nil
+}
+
+// signedSections - function to get workload, env and envWorkloadSignature from final contract
+func signedSections(contract string) (string, string, string, error) {
+	var contractMap map[string]interface{}
+
+	err := yaml.Unmarshal([]byte(contract), &contractMap)
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to unmarshal YAML - %v", err)
+	}
+
+	workload, err := sectionValue(contractMap, "workload")
+	if err != nil {
+		return "", "", "", err
+	}
+
+	env, err := sectionValue(contractMap, "env")
+	if err != nil {
+		return "", "", "", err
+	}
+
+	workloadEnvSignature, ok := contractMap["envWorkloadSignature"].(string)
+	if !ok || workloadEnvSignature == "" {
+		return "", "", "", fmt.Errorf("envWorkloadSignature is missing in contract")
+	}
+
+	return workload, env, workloadEnvSignature, nil
+}
+
+// sectionValue - function to get the value of section as it is signed, plain sections are signed as YAML
+func sectionValue(contractMap map[string]interface{}, key string) (string, error) {
+	switch value := contractMap[key].(type) {
+	case string:
+		return value, nil
+	case map[string]interface{}:
+		return gen.MapToYaml(value)
+	case nil:
+		return "", fmt.Errorf("%s is missing in contract", key)
+	default:
+		return "", fmt.Errorf("%s has unexpected type %T", key, value)
+	}
+}
+
+// verifySigningCertificate - function to check validity window of signing certificate and its chain to CA
+func verifySigningCertificate(certificate *x509.Certificate, caCert string, now time.Time) error {
+	if now.Before(certificate.NotBefore) {
+		return fmt.Errorf("signing certificate is not valid before %s", certificate.NotBefore.UTC().Format(time.RFC3339))
+	}
+
+	if now.After(certificate.NotAfter) {
+		return fmt.Errorf("signing certificate expired on %s", certificate.NotAfter.UTC().Format(time.RFC3339))
+	}
+
+	if caCert == "" {
+		return nil
+	}
+
+	caCertificates, err := gen.ParseCertificates(caCert)
+	if err != nil {
+		return fmt.Errorf("failed to parse CA certificate - %v", err)
+	}
+
+	roots := x509.NewCertPool()
+	for _, caCertificate := range caCertificates {
+		roots.AddCert(caCertificate)
+	}
+
+	_, err = certificate.Verify(x509.VerifyOptions{
+		Roots:       roots,
+		CurrentTime: now,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return fmt.Errorf("signing certificate is not issued by CA - %v", err)
+	}
+
+	return nil
+}
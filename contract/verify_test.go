@@ -0,0 +1,104 @@
+package contract
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	enc "github.com/Sashwat-K/lib-hpcr/common/encrypt"
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+)
+
+// Testcase to check if HpcrVerifyContractSignature() verifies contract signed with private key
+func TestHpcrVerifyContractSignature(t *testing.T) {
+	contract, privateKey, publicKey, _, _, err := common("TestEncryptWrapper")
+	if err != nil {
+		t.Errorf("failed to get contract, private key and public key - %v", err)
+	}
+
+	result, err := EncryptWrapper(contract, "", privateKey, publicKey)
+	if err != nil {
+		t.Errorf("failed to sign and encrypt contract - %v", err)
+	}
+
+	assert.NoError(t, HpcrVerifyContractSignature(result, publicKey, ""))
+
+	tampered, err := gen.KeyValueInjector(map[string]interface{}{"workload": hpcrEncryptPrefix + "a.b", "env": hpcrEncryptPrefix + "c.d"}, "envWorkloadSignature", sampleBase64Data)
+	if err != nil {
+		t.Errorf("failed to generate contract - %v", err)
+	}
+
+	assert.Error(t, HpcrVerifyContractSignature(tampered, publicKey, ""))
+}
+
+// Testcase to check if HpcrVerifyContractSignature() verifies contract expiry contract and its CA
+func TestHpcrVerifyContractSignatureContractExpiry(t *testing.T) {
+	contract, privateKey, _, caCert, caKey, err := common("TestHpcrContractSignedEncryptedContractExpiryCsrParams")
+	if err != nil {
+		t.Errorf("failed to get contract, private key, CA certificate and CA key - %v", err)
+	}
+
+	csrParams, err := json.Marshal(sampleCeCSRPems)
+	if err != nil {
+		t.Errorf("failed to unmarshal CSR parameters - %v", err)
+	}
+
+	signingCert, err := enc.CreateSigningCert(privateKey, caCert, caKey, string(csrParams), "", sampleContractExpiryDays)
+	if err != nil {
+		t.Errorf("failed to generate signing certificate - %v", err)
+	}
+
+	result, err := EncryptWrapper(contract, "", privateKey, signingCert)
+	if err != nil {
+		t.Errorf("failed to sign and encrypt contract - %v", err)
+	}
+
+	otherCa, err := gen.ReadDataFromFile(samplePublicKeyCertPath)
+	if err != nil {
+		t.Errorf("failed to read certificate - %v", err)
+	}
+
+	assert.NoError(t, HpcrVerifyContractSignature(result, signingCert, ""))
+	assert.NoError(t, HpcrVerifyContractSignature(result, signingCert, caCert))
+	assert.Error(t, HpcrVerifyContractSignature(result, signingCert, otherCa))
+}
+
+// Testcase to check if verifySigningCertificate() rejects expired certificate
+func TestVerifySigningCertificate(t *testing.T) {
+	privateKey, err := gen.ReadDataFromFile(samplePrivateKeyPath)
+	if err != nil {
+		t.Errorf("failed to read private key - %v", err)
+	}
+
+	rsaKey, err := gen.ParseRsaPrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("failed to parse private key - %v", err)
+	}
+
+	notBefore := time.Now().Add(-48 * time.Hour)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: sampleStringData},
+		NotBefore:    notBefore,
+		NotAfter:     notBefore.Add(24 * time.Hour),
+	}
+
+	certificateDer, err := x509.CreateCertificate(rand.Reader, template, template, &rsaKey.PublicKey, rsaKey)
+	if err != nil {
+		t.Fatalf("failed to create certificate - %v", err)
+	}
+
+	certificate, err := x509.ParseCertificate(certificateDer)
+	if err != nil {
+		t.Fatalf("failed to parse certificate - %v", err)
+	}
+
+	assert.Error(t, verifySigningCertificate(certificate, "", time.Now()))
+	assert.NoError(t, verifySigningCertificate(certificate, "", notBefore.Add(time.Hour)))
+}
@@ -6,8 +6,10 @@ require (
 	github.com/Masterminds/semver/v3 v3.2.1
 	github.com/Sashwat-K/hpcr-contract-schema v1.0.4
 	github.com/Sashwat-K/hpcr-encryption-certificate v1.0.7
+	github.com/miekg/pkcs11 v1.1.1
 	github.com/stretchr/testify v1.9.0
 	github.com/xeipuuv/gojsonschema v1.2.0
+	golang.org/x/crypto v0.31.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 
@@ -0,0 +1,235 @@
+package simulator
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Sashwat-K/lib-hpcr/common/envelope"
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+	prov "github.com/Sashwat-K/lib-hpcr/common/provider"
+	"github.com/Sashwat-K/lib-hpcr/contract"
+)
+
+const (
+	missingParameterErrStatement = "required parameter is missing"
+)
+
+// names of checks in Report
+const (
+	CheckDecryptWorkload = "decrypt-workload"
+	CheckDecryptEnv      = "decrypt-env"
+	CheckSchemaWorkload  = "schema-workload"
+	CheckSchemaEnv       = "schema-env"
+	CheckSignature       = "signature"
+	CheckArchive         = "archive"
+)
+
+type (
+	// Options - optional settings for simulation
+	Options struct {
+		// Provider - crypto implementation to use, openssl is used if nil
+		Provider prov.CryptoProvider
+		// CaCert - CA certificate to validate contract expiry signing certificate against (optional)
+		CaCert string
+	}
+
+	// Check - result of one step of the simulated boot
+	Check struct {
+		Name    string `json:"name"`
+		Passed  bool   `json:"passed"`
+		Message string `json:"message,omitempty"`
+	}
+
+	// Report - result of simulated boot
+	Report struct {
+		// Valid - true if all checks passed
+		Valid bool `json:"valid"`
+		// Checks - checks in the order they were run
+		Checks []Check `json:"checks"`
+		// Workload - decrypted workload section
+		Workload string `json:"workload,omitempty"`
+		// Env - decrypted env section
+		Env string `json:"env,omitempty"`
+		// ArchiveFiles - names of entries in compose or play archive
+		ArchiveFiles []string `json:"archiveFiles,omitempty"`
+	}
+)
+
+// HpcrSimulateContract - function to do what HPCR does with a contract at boot, using the private key of a test encryption certificate
+func HpcrSimulateContract(contractData, privateKey string, opts Options) (Report, error) {
+	if gen.CheckIfEmpty(contractData, privateKey) {
+		return Report{}, fmt.Errorf(missingParameterErrStatement)
+	}
+
+	var contractMap map[string]interface{}
+	err := yaml.Unmarshal([]byte(contractData), &contractMap)
+	if err != nil {
+		return Report{}, fmt.Errorf("failed to unmarshal YAML - %v", err)
+	}
+
+	report := &Report{Valid: true}
+	cryptoProvider := prov.GetProvider(opts.Provider)
+
+	workload, err := decryptSection(contractMap["workload"], privateKey, cryptoProvider)
+	report.add(CheckDecryptWorkload, err)
+	report.Workload = workload
+
+	env, err := decryptSection(contractMap["env"], privateKey, cryptoProvider)
+	report.add(CheckDecryptEnv, err)
+	report.Env = env
+
+	workloadMap, err := parseSection(workload, "workload")
+	report.add(CheckSchemaWorkload, err)
+
+	envMap, err := parseSection(env, "env")
+	report.add(CheckSchemaEnv, err)
+
+	report.add(CheckSignature, verifySignature(contractData, contractMap, envMap, opts.CaCert))
+
+	archiveFiles, err := readArchive(workloadMap)
+	report.add(CheckArchive, err)
+	report.ArchiveFiles = archiveFiles
+
+	return *report, nil
+}
+
+// add - function to record result of check
+func (r *Report) add(name string, err error) {
+	check := Check{Name: name, Passed: err == nil}
+	if err != nil {
+		check.Message = err.Error()
+		r.Valid = false
+	}
+
+	r.Checks = append(r.Checks, check)
+}
+
+// decryptSection - function to decrypt hyper-protect-basic section, plain sections are returned as YAML
+func decryptSection(section interface{}, privateKey string, cryptoProvider prov.CryptoProvider) (string, error) {
+	switch value := section.(type) {
+	case nil:
+		return "", fmt.Errorf("section is missing")
+	case map[string]interface{}:
+		return gen.MapToYaml(value)
+	case string:
+		encryptedSection, err := envelope.Parse(value)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse encrypted section - %v", err)
+		}
+
+		password, err := cryptoProvider.DecryptPassword(encryptedSection.Password, privateKey)
+		if err != nil {
+			return "", fmt.Errorf("failed to decrypt password - %v", err)
+		}
+
+		data, err := cryptoProvider.DecryptWorkload(password, encryptedSection.Data)
+		if err != nil {
+			return "", fmt.Errorf("failed to decrypt data - %v", err)
+		}
+
+		return data, nil
+	default:
+		return "", fmt.Errorf("section has unexpected type %T", value)
+	}
+}
+
+// parseSection - function to parse decrypted section and validate it against contract schema
+func parseSection(section, key string) (map[string]interface{}, error) {
+	if section == "" {
+		return nil, fmt.Errorf("%s is not available", key)
+	}
+
+	var sectionMap map[string]interface{}
+	err := yaml.Unmarshal([]byte(section), &sectionMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s - %v", key, err)
+	}
+
+	sectionContract, err := gen.MapToYaml(map[string]interface{}{key: sectionMap})
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert MAP to YAML - %v", err)
+	}
+
+	err = gen.VerifyContractWithSchema(sectionContract)
+	if err != nil {
+		return nil, err
+	}
+
+	return sectionMap, nil
+}
+
+// verifySignature - function to verify envWorkloadSignature against signingKey from decrypted env
+func verifySignature(contractData string, contractMap, envMap map[string]interface{}, caCert string) error {
+	_, signed := contractMap["envWorkloadSignature"]
+	signingKey, _ := envMap["signingKey"].(string)
+
+	if !signed && signingKey == "" {
+		return nil
+	}
+
+	if !signed {
+		return fmt.Errorf("env has signingKey but contract doesn't have envWorkloadSignature")
+	}
+
+	if signingKey == "" {
+		return fmt.Errorf("contract has envWorkloadSignature but env doesn't have signingKey")
+	}
+
+	return contract.HpcrVerifyContractSignature(contractData, signingKey, caCert)
+}
+
+// readArchive - function to unpack compose or play archive of workload
+func readArchive(workloadMap map[string]interface{}) ([]string, error) {
+	if workloadMap == nil {
+		return nil, fmt.Errorf("workload is not available")
+	}
+
+	for _, key := range []string{"compose", "play"} {
+		section, ok := workloadMap[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		archive, ok := section["archive"].(string)
+		if !ok {
+			if key == "compose" {
+				return nil, fmt.Errorf("compose archive is missing")
+			}
+
+			// play can give resources or templates instead of archive
+			return nil, nil
+		}
+
+		entries, err := gen.ReadTgzBase64(archive)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unpack %s archive - %v", key, err)
+		}
+
+		var files []string
+		for _, entry := range entries {
+			files = append(files, entry.Name)
+		}
+
+		if key == "compose" && !containsComposeFile(files) {
+			return files, fmt.Errorf("compose archive doesn't contain docker-compose.yml or docker-compose.yaml")
+		}
+
+		return files, nil
+	}
+
+	return nil, nil
+}
+
+// containsComposeFile - function to check if compose file is at top level of archive
+func containsComposeFile(files []string) bool {
+	for _, file := range files {
+		name := strings.TrimPrefix(file, "./")
+		if name == "docker-compose.yml" || name == "docker-compose.yaml" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,141 @@
+package simulator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+	prov "github.com/Sashwat-K/lib-hpcr/common/provider"
+	"github.com/Sashwat-K/lib-hpcr/contract"
+)
+
+const (
+	sampleCertificatePath   = "../samples/encrypt/certificate.crt"
+	samplePrivateKeyPath    = "../samples/encrypt/private.pem"
+	sampleOtherPrivateKey   = "../samples/attestation/private.pem"
+	sampleComposeFolderPath = "../samples/tgz"
+)
+
+// sampleContract - function to generate signed and encrypted contract for test encryption certificate
+func sampleContract(t *testing.T) (string, string) {
+	certificate, err := gen.ReadDataFromFile(sampleCertificatePath)
+	if err != nil {
+		t.Errorf("failed to read certificate - %v", err)
+	}
+
+	privateKey, err := gen.ReadDataFromFile(samplePrivateKeyPath)
+	if err != nil {
+		t.Errorf("failed to read private key - %v", err)
+	}
+
+	archive, _, _, err := contract.HpcrTgz(sampleComposeFolderPath)
+	if err != nil {
+		t.Errorf("failed to generate archive - %v", err)
+	}
+
+	contractMap := map[string]interface{}{
+		"workload": map[string]interface{}{
+			"type":    "workload",
+			"compose": map[string]interface{}{"archive": archive},
+		},
+		"env": map[string]interface{}{
+			"type": "env",
+			"logging": map[string]interface{}{
+				"logDNA": map[string]interface{}{
+					"hostname":     "syslog-a.eu-de.logging.cloud.ibm.com",
+					"ingestionKey": "ab00e3c09p1d4ff7fff9f04c12183413",
+				},
+			},
+		},
+	}
+
+	contractYaml, err := gen.MapToYaml(contractMap)
+	if err != nil {
+		t.Errorf("failed to generate contract - %v", err)
+	}
+
+	result, _, _, err := contract.HpcrContractSignedEncryptedWithOptions(contractYaml, certificate, privateKey, contract.Options{Provider: prov.NativeProvider{}})
+	if err != nil {
+		t.Fatalf("failed to generate signed and encrypted contract - %v", err)
+	}
+
+	return result, privateKey
+}
+
+// Testcase to check if HpcrSimulateContract() accepts contract generated by the library
+func TestHpcrSimulateContract(t *testing.T) {
+	contractData, privateKey := sampleContract(t)
+
+	report, err := HpcrSimulateContract(contractData, privateKey, Options{Provider: prov.NativeProvider{}})
+	if err != nil {
+		t.Errorf("failed to simulate contract - %v", err)
+	}
+
+	assert.True(t, report.Valid, report.Checks)
+	assert.Contains(t, report.Env, "signingKey")
+	assert.Equal(t, []string{"docker-compose.yaml"}, report.ArchiveFiles)
+}
+
+// Testcase to check if HpcrSimulateContract() reports contract encrypted for another key
+func TestHpcrSimulateContractWrongKey(t *testing.T) {
+	contractData, _ := sampleContract(t)
+
+	privateKey, err := gen.ReadDataFromFile(sampleOtherPrivateKey)
+	if err != nil {
+		t.Errorf("failed to read private key - %v", err)
+	}
+
+	report, err := HpcrSimulateContract(contractData, privateKey, Options{Provider: prov.NativeProvider{}})
+	if err != nil {
+		t.Errorf("failed to simulate contract - %v", err)
+	}
+
+	assert.False(t, report.Valid)
+	assert.Equal(t, CheckDecryptWorkload, report.Checks[0].Name)
+	assert.False(t, report.Checks[0].Passed)
+}
+
+// Testcase to check if HpcrSimulateContract() reports tampered signature
+func TestHpcrSimulateContractTampered(t *testing.T) {
+	contractData, privateKey := sampleContract(t)
+
+	workload, env, _ := splitContract(t, contractData)
+	tampered, err := gen.MapToYaml(map[string]interface{}{"workload": workload, "env": env, "envWorkloadSignature": gen.EncodeToBase64("invalid")})
+	if err != nil {
+		t.Errorf("failed to generate contract - %v", err)
+	}
+
+	report, err := HpcrSimulateContract(tampered, privateKey, Options{Provider: prov.NativeProvider{}})
+	if err != nil {
+		t.Errorf("failed to simulate contract - %v", err)
+	}
+
+	assert.False(t, report.Valid)
+	for _, check := range report.Checks {
+		assert.Equal(t, check.Name != CheckSignature, check.Passed, check.Name)
+	}
+}
+
+// Testcase to check if readArchive() returns error for compose section without archive
+func TestReadArchiveMissing(t *testing.T) {
+	_, err := readArchive(map[string]interface{}{"type": "workload", "compose": map[string]interface{}{}})
+	assert.ErrorContains(t, err, "compose archive is missing")
+
+	result, err := readArchive(map[string]interface{}{"type": "workload", "play": map[string]interface{}{"resources": []interface{}{}}})
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+// splitContract - function to get sections of final contract
+func splitContract(t *testing.T, contractData string) (interface{}, interface{}, interface{}) {
+	var contractMap map[string]interface{}
+
+	err := yaml.Unmarshal([]byte(contractData), &contractMap)
+	if err != nil {
+		t.Errorf("failed to unmarshal contract - %v", err)
+	}
+
+	return contractMap["workload"], contractMap["env"], contractMap["envWorkloadSignature"]
+}
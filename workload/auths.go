@@ -0,0 +1,203 @@
+package workload
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+	"github.com/Sashwat-K/lib-hpcr/contract"
+)
+
+// rule of registry credential findings
+const (
+	RuleRegistryAuth = "registry-auth"
+)
+
+var (
+	// reRegistryHost tests if registry is a valid hostname or IP address with optional port, single label hosts (eg: registry:5000) need localhost or a port
+	reRegistryHost = regexp.MustCompile(`^((localhost|[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)+)(:[0-9]{1,5})?|[a-z0-9]([a-z0-9-]*[a-z0-9])?:[0-9]{1,5})$`)
+
+	// dockerHubHosts - hosts of Docker Hub used in config.json, images refer to them as docker.io
+	dockerHubHosts = map[string]bool{
+		"index.docker.io":      true,
+		"registry-1.docker.io": true,
+	}
+)
+
+type (
+	// RegistryCredential - credential of container registry
+	RegistryCredential struct {
+		Registry string
+		Username string
+		Password string
+	}
+
+	// dockerConfig - config.json of Docker CLI
+	dockerConfig struct {
+		Auths map[string]struct {
+			Auth     string `json:"auth"`
+			Username string `json:"username"`
+			Password string `json:"password"`
+		} `json:"auths"`
+	}
+)
+
+// HpcrRegistryAuths - function to generate auths of workload section from list of registry credentials
+func HpcrRegistryAuths(credentials []RegistryCredential) (map[string]contract.Auth, error) {
+	if len(credentials) == 0 {
+		return nil, fmt.Errorf(missingParameterErrStatement)
+	}
+
+	auths := map[string]contract.Auth{}
+
+	for _, credential := range credentials {
+		registry, err := normalizeRegistry(credential.Registry)
+		if err != nil {
+			return nil, err
+		}
+
+		if gen.CheckIfEmpty(credential.Username, credential.Password) {
+			return nil, fmt.Errorf("username or password of registry %s is missing", registry)
+		}
+
+		if _, ok := auths[registry]; ok {
+			return nil, fmt.Errorf("registry %s has more than one credential", registry)
+		}
+
+		auths[registry] = contract.Auth{Username: credential.Username, Password: credential.Password}
+	}
+
+	return auths, nil
+}
+
+// HpcrRegistryAuthsFromDockerConfig - function to generate auths of workload section from Docker config.json
+// Entries without auth or username and password (eg: managed by credsStore) are skipped
+func HpcrRegistryAuthsFromDockerConfig(configJson string) (map[string]contract.Auth, error) {
+	if gen.CheckIfEmpty(configJson) {
+		return nil, fmt.Errorf(missingParameterErrStatement)
+	}
+
+	var config dockerConfig
+	err := json.Unmarshal([]byte(configJson), &config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Docker config - %v", err)
+	}
+
+	var credentials []RegistryCredential
+
+	for registry, entry := range config.Auths {
+		credential := RegistryCredential{Registry: registry, Username: entry.Username, Password: entry.Password}
+
+		if entry.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode auth of registry %s - %v", registry, err)
+			}
+
+			username, password, ok := strings.Cut(string(decoded), ":")
+			if !ok {
+				return nil, fmt.Errorf("auth of registry %s is not in username:password format", registry)
+			}
+
+			credential.Username, credential.Password = username, password
+		}
+
+		if credential.Username == "" && credential.Password == "" {
+			continue
+		}
+
+		credentials = append(credentials, credential)
+	}
+
+	if len(credentials) == 0 {
+		return nil, fmt.Errorf("Docker config doesn't have registry credentials")
+	}
+
+	sort.Slice(credentials, func(i, j int) bool {
+		return credentials[i].Registry < credentials[j].Registry
+	})
+
+	return HpcrRegistryAuths(credentials)
+}
+
+// HpcrCheckRegistryAuths - function to report registries of images in compose or play archive that have no credential in auths
+// Missing credentials are warnings, as images of public registries can be pulled without them
+func HpcrCheckRegistryAuths(auths map[string]contract.Auth, tgzBase64 string) ([]Finding, error) {
+	if gen.CheckIfEmpty(tgzBase64) {
+		return nil, fmt.Errorf(missingParameterErrStatement)
+	}
+
+	images, err := HpcrArchiveImages(tgzBase64)
+	if err != nil {
+		return nil, err
+	}
+
+	// keys written by hand may be config.json style (eg: https://index.docker.io/v1/)
+	registries := map[string]bool{}
+	for key := range auths {
+		registry, err := normalizeRegistry(key)
+		if err != nil {
+			return nil, err
+		}
+		registries[registry] = true
+	}
+
+	missing := map[string]string{}
+
+	for _, image := range images {
+		reference, err := ParseImageReference(image)
+		if err != nil {
+			return nil, err
+		}
+
+		registry := reference.Registry
+		if registry == "" {
+			registry = defaultRegistry
+		}
+
+		if !registries[registry] {
+			if _, reported := missing[registry]; !reported {
+				missing[registry] = image
+			}
+		}
+	}
+
+	missingRegistries := make([]string, 0, len(missing))
+	for registry := range missing {
+		missingRegistries = append(missingRegistries, registry)
+	}
+	sort.Strings(missingRegistries)
+
+	var findings []Finding
+	for _, registry := range missingRegistries {
+		findings = append(findings, Finding{
+			Severity: SeverityWarning,
+			Rule:     RuleRegistryAuth,
+			Message:  fmt.Sprintf("registry %s of image %s has no credential", registry, missing[registry]),
+		})
+	}
+
+	return findings, nil
+}
+
+// normalizeRegistry - function to get hostname of registry from config.json key (eg: https://index.docker.io/v1/) and validate it
+func normalizeRegistry(registry string) (string, error) {
+	host := strings.ToLower(strings.TrimSpace(registry))
+	host = strings.TrimPrefix(host, "https://")
+	host = strings.TrimPrefix(host, "http://")
+	host, _, _ = strings.Cut(host, "/")
+
+	if dockerHubHosts[host] {
+		host = defaultRegistry
+	}
+
+	if !reRegistryHost.MatchString(host) {
+		return "", fmt.Errorf("invalid registry hostname - %q", registry)
+	}
+
+	return host, nil
+}
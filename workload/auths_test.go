@@ -0,0 +1,134 @@
+package workload
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Sashwat-K/lib-hpcr/contract"
+)
+
+const (
+	sampleDockerConfig = `{
+  "auths": {
+    "https://index.docker.io/v1/": {
+      "auth": "dXNlcjp0b2tlbjp3aXRoOmNvbG9ucw=="
+    },
+    "us.icr.io": {
+      "username": "iamapikey",
+      "password": "apikey"
+    },
+    "ghcr.io": {}
+  },
+  "credsStore": "desktop"
+}`
+
+	sampleMultiRegistryCompose = `services:
+  app:
+    image: us.icr.io/example/app:1.0
+  cache:
+    image: redis:7
+  web:
+    image: quay.io/example/web:1.0
+  proxy:
+    image: quay.io/example/proxy:1.0
+`
+)
+
+// Testcase to check if HpcrRegistryAuths() validates hostnames and credentials
+func TestHpcrRegistryAuths(t *testing.T) {
+	result, err := HpcrRegistryAuths([]RegistryCredential{
+		{Registry: "us.icr.io", Username: "iamapikey", Password: "apikey"},
+		{Registry: "localhost:5000", Username: "user", Password: "password"},
+		{Registry: "myregistry:5000", Username: "user", Password: "password"},
+	})
+	if err != nil {
+		t.Errorf("failed to generate registry auths - %v", err)
+	}
+
+	assert.Equal(t, result, map[string]contract.Auth{
+		"us.icr.io":       {Username: "iamapikey", Password: "apikey"},
+		"localhost:5000":  {Username: "user", Password: "password"},
+		"myregistry:5000": {Username: "user", Password: "password"},
+	})
+
+	_, err = HpcrRegistryAuths([]RegistryCredential{{Registry: "not a host", Username: "user", Password: "password"}})
+	assert.Error(t, err)
+
+	_, err = HpcrRegistryAuths([]RegistryCredential{{Registry: "myregistry", Username: "user", Password: "password"}})
+	assert.Error(t, err)
+
+	_, err = HpcrRegistryAuths([]RegistryCredential{{Registry: "us.icr.io", Username: "iamapikey"}})
+	assert.Error(t, err)
+
+	_, err = HpcrRegistryAuths([]RegistryCredential{
+		{Registry: "https://index.docker.io/v1/", Username: "user", Password: "password"},
+		{Registry: "docker.io", Username: "user", Password: "password"},
+	})
+	assert.Error(t, err)
+}
+
+// Testcase to check if HpcrRegistryAuthsFromDockerConfig() reads auth and username/password entries of config.json
+func TestHpcrRegistryAuthsFromDockerConfig(t *testing.T) {
+	result, err := HpcrRegistryAuthsFromDockerConfig(sampleDockerConfig)
+	if err != nil {
+		t.Errorf("failed to generate registry auths - %v", err)
+	}
+
+	assert.Equal(t, result, map[string]contract.Auth{
+		"docker.io": {Username: "user", Password: "token:with:colons"},
+		"us.icr.io": {Username: "iamapikey", Password: "apikey"},
+	})
+
+	_, err = HpcrRegistryAuthsFromDockerConfig(`{"auths": {"us.icr.io": {"auth": "bm9jb2xvbg=="}}}`)
+	assert.Error(t, err)
+
+	_, err = HpcrRegistryAuthsFromDockerConfig(`{"credsStore": "desktop"}`)
+	assert.Error(t, err)
+}
+
+// Testcase to check if HpcrCheckRegistryAuths() warns once per registry without credential
+func TestHpcrCheckRegistryAuths(t *testing.T) {
+	auths, err := HpcrRegistryAuthsFromDockerConfig(sampleDockerConfig)
+	if err != nil {
+		t.Errorf("failed to generate registry auths - %v", err)
+	}
+
+	archive, _, _, err := contract.HpcrTgzFromMap(map[string]string{"docker-compose.yaml": sampleMultiRegistryCompose}, contract.Options{})
+	if err != nil {
+		t.Errorf("failed to generate archive - %v", err)
+	}
+
+	result, err := HpcrCheckRegistryAuths(auths, archive)
+	if err != nil {
+		t.Errorf("failed to check registry auths - %v", err)
+	}
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, result[0].Rule, RuleRegistryAuth)
+	assert.Equal(t, result[0].Severity, SeverityWarning)
+	assert.Contains(t, result[0].Message, "registry quay.io")
+
+	// keys written by hand in config.json style are normalized
+	result, err = HpcrCheckRegistryAuths(map[string]contract.Auth{
+		"https://index.docker.io/v1/": {Username: "user", Password: "password"},
+		"us.icr.io":                   {Username: "iamapikey", Password: "apikey"},
+	}, archive)
+	if err != nil {
+		t.Errorf("failed to check registry auths - %v", err)
+	}
+
+	assert.Len(t, result, 1)
+	assert.Contains(t, result[0].Message, "registry quay.io")
+
+	typedContract, err := contract.NewContractBuilder().
+		WithComposeArchive(archive).
+		WithAuths(auths).
+		WithLogDNA("syslog-a.eu-de.logging.cloud.ibm.com", "cd4b1cfbf7c28e03e9d0b1a6ef5e8d4c").
+		Build()
+	if err != nil {
+		t.Errorf("failed to build contract - %v", err)
+	}
+
+	assert.Equal(t, typedContract.Workload.Auths["docker.io"].Username, "user")
+}
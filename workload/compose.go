@@ -0,0 +1,352 @@
+package workload
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+	"github.com/Sashwat-K/lib-hpcr/contract"
+)
+
+// severities of findings
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// rules of compose findings
+const (
+	RuleComposeFile = "compose-file"
+	RuleBuild       = "build"
+	RuleImage       = "image"
+	RuleMutableTag  = "mutable-tag"
+	RuleBindMount   = "bind-mount"
+	RulePrivileged  = "privileged"
+	RuleEnvFile     = "env-file"
+)
+
+var (
+	// composeFileNames - names of compose file HPCR looks for at top level of archive
+	composeFileNames = []string{"docker-compose.yaml", "docker-compose.yml"}
+)
+
+type (
+	// Finding - problem found in workload
+	Finding struct {
+		Severity string `json:"severity"`
+		Rule     string `json:"rule"`
+		Service  string `json:"service,omitempty"`
+		Message  string `json:"message"`
+	}
+
+	// ComposeOptions - settings for compose validation
+	ComposeOptions struct {
+		// AllowedBindPaths - absolute host paths that may be bind mounted (eg: mount points of data volumes), paths inside compose folder are always allowed
+		AllowedBindPaths []string
+		// RequireDigests - report images without digest as error instead of warning
+		RequireDigests bool
+	}
+
+	// workloadFiles - files of compose folder or archive that compose file can refer to
+	workloadFiles struct {
+		// location - name of the place files are looked up in, used in messages
+		location string
+		// exists - function to check if slash separated path relative to compose file is in workload
+		exists func(relPath string) bool
+	}
+)
+
+// HpcrComposeValidate - function to parse compose file of folder and report constructs HPCR can't run
+func HpcrComposeValidate(folderPath string, opts ComposeOptions) ([]Finding, error) {
+	if gen.CheckIfEmpty(folderPath) {
+		return nil, fmt.Errorf(missingParameterErrStatement)
+	}
+
+	if !gen.CheckFileFolderExists(folderPath) {
+		return nil, fmt.Errorf("folder doesn't exists - %s", folderPath)
+	}
+
+	composeFile := ""
+	for _, name := range composeFileNames {
+		if gen.CheckFileFolderExists(filepath.Join(folderPath, name)) {
+			composeFile = name
+			break
+		}
+	}
+
+	if composeFile == "" {
+		return []Finding{{Severity: SeverityError, Rule: RuleComposeFile, Message: "docker-compose.yaml or docker-compose.yml is missing"}}, nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(folderPath, composeFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s - %v", composeFile, err)
+	}
+
+	folder := workloadFiles{location: "compose folder", exists: func(relPath string) bool {
+		return gen.CheckFileFolderExists(filepath.Join(folderPath, filepath.FromSlash(relPath)))
+	}}
+
+	return validateCompose(composeFile, data, folder, opts), nil
+}
+
+// HpcrComposeArchive - function to generate base64 archive of compose folder and return it only if there are no error findings
+// The compose file, env_file and bind mounts are validated against the entries of the archive, so files left out by
+// .hpcrignore or include and exclude patterns are reported as missing
+func HpcrComposeArchive(folderPath string, composeOpts ComposeOptions, opts contract.Options) (string, string, string, []Finding, error) {
+	if gen.CheckIfEmpty(folderPath) {
+		return "", "", "", nil, fmt.Errorf(missingParameterErrStatement)
+	}
+
+	tgzBase64, inputSha256, outputSha256, err := contract.HpcrTgzWithOptions(folderPath, opts)
+	if err != nil {
+		return "", "", "", nil, err
+	}
+
+	findings, err := validateComposeArchive(tgzBase64, composeOpts)
+	if err != nil {
+		return "", "", "", nil, err
+	}
+
+	if HasErrors(findings) {
+		return "", "", "", findings, fmt.Errorf("compose validation failed with %d error(s)", countErrors(findings))
+	}
+
+	return tgzBase64, inputSha256, outputSha256, findings, nil
+}
+
+// validateComposeArchive - function to validate compose file of base64 archive against entries of the archive
+func validateComposeArchive(tgzBase64 string, opts ComposeOptions) ([]Finding, error) {
+	entries, err := gen.ReadTgzBase64(tgzBase64)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{".": true}
+	contents := map[string]string{}
+
+	for _, entry := range entries {
+		name := path.Clean(entry.Name)
+		contents[name] = entry.Content
+
+		for ; name != "." && !names[name]; name = path.Dir(name) {
+			names[name] = true
+		}
+	}
+
+	composeFile := ""
+	for _, name := range composeFileNames {
+		if _, ok := contents[name]; ok {
+			composeFile = name
+			break
+		}
+	}
+
+	if composeFile == "" {
+		return []Finding{{Severity: SeverityError, Rule: RuleComposeFile, Message: "docker-compose.yaml or docker-compose.yml is missing in archive"}}, nil
+	}
+
+	archive := workloadFiles{location: "archive", exists: func(relPath string) bool {
+		return names[path.Clean(relPath)]
+	}}
+
+	return validateCompose(composeFile, []byte(contents[composeFile]), archive, opts), nil
+}
+
+// validateCompose - function to parse compose file and check its services against files of workload
+func validateCompose(composeFile string, data []byte, files workloadFiles, opts ComposeOptions) []Finding {
+	var compose map[string]interface{}
+	err := yaml.Unmarshal(data, &compose)
+	if err != nil {
+		return []Finding{{Severity: SeverityError, Rule: RuleComposeFile, Message: fmt.Sprintf("failed to parse %s - %v", composeFile, err)}}
+	}
+
+	services, _ := compose["services"].(map[string]interface{})
+	if len(services) == 0 {
+		return []Finding{{Severity: SeverityError, Rule: RuleComposeFile, Message: fmt.Sprintf("%s doesn't have services", composeFile)}}
+	}
+
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var findings []Finding
+	for _, name := range names {
+		service, _ := services[name].(map[string]interface{})
+		findings = append(findings, validateComposeService(files, name, service, opts)...)
+	}
+
+	return findings
+}
+
+// HasErrors - function to check if any finding has error severity
+func HasErrors(findings []Finding) bool {
+	return countErrors(findings) > 0
+}
+
+// countErrors - function to count findings with error severity
+func countErrors(findings []Finding) int {
+	count := 0
+	for _, finding := range findings {
+		if finding.Severity == SeverityError {
+			count++
+		}
+	}
+
+	return count
+}
+
+// validateComposeService - function to check one service of compose file
+func validateComposeService(files workloadFiles, name string, service map[string]interface{}, opts ComposeOptions) []Finding {
+	var findings []Finding
+
+	add := func(severity, rule, message string, args ...interface{}) {
+		findings = append(findings, Finding{Severity: severity, Rule: rule, Service: name, Message: fmt.Sprintf(message, args...)})
+	}
+
+	if _, ok := service["build"]; ok {
+		add(SeverityError, RuleBuild, "build is not supported, use a prebuilt image")
+	}
+
+	if privileged, _ := service["privileged"].(bool); privileged {
+		add(SeverityError, RulePrivileged, "privileged containers are not allowed")
+	}
+
+	image, _ := service["image"].(string)
+	if image == "" {
+		add(SeverityError, RuleImage, "image is missing")
+	} else {
+		reference, err := ParseImageReference(image)
+		if err != nil {
+			add(SeverityError, RuleImage, "%v", err)
+		} else {
+			if !reference.IsFullyQualified() {
+				add(SeverityWarning, RuleImage, "image %s is not fully qualified, registry is missing", image)
+			}
+
+			if reference.Digest == "" {
+				severity := SeverityWarning
+				if opts.RequireDigests {
+					severity = SeverityError
+				}
+				add(severity, RuleMutableTag, "image %s is referenced by tag instead of digest", image)
+			}
+		}
+	}
+
+	volumes, _ := service["volumes"].([]interface{})
+	for _, volume := range volumes {
+		source, isBind := bindMountSource(volume)
+		if !isBind {
+			continue
+		}
+
+		err := checkBindMount(files, source, opts.AllowedBindPaths)
+		if err != nil {
+			add(SeverityError, RuleBindMount, "%v", err)
+		}
+	}
+
+	for _, envFile := range envFiles(service["env_file"]) {
+		if path.IsAbs(envFile) || !isInsideFolder(envFile) {
+			add(SeverityError, RuleEnvFile, "env_file %s is outside of compose folder", envFile)
+		} else if !files.exists(envFile) {
+			add(SeverityError, RuleEnvFile, "env_file %s doesn't exist in %s", envFile, files.location)
+		}
+	}
+
+	return findings
+}
+
+// bindMountSource - function to get host path of volume if it is a bind mount, in short ("src:dst:mode") or long syntax
+func bindMountSource(volume interface{}) (string, bool) {
+	switch value := volume.(type) {
+	case string:
+		parts := strings.SplitN(value, ":", 2)
+		if len(parts) < 2 {
+			return "", false
+		}
+
+		source := parts[0]
+		if strings.HasPrefix(source, "/") || strings.HasPrefix(source, ".") || strings.HasPrefix(source, "~") {
+			return source, true
+		}
+	case map[string]interface{}:
+		if volumeType, _ := value["type"].(string); volumeType == "bind" {
+			source, _ := value["source"].(string)
+			return source, true
+		}
+	}
+
+	return "", false
+}
+
+// checkBindMount - function to check that bind mount source is inside compose folder or under allowed path
+func checkBindMount(files workloadFiles, source string, allowedBindPaths []string) error {
+	if strings.HasPrefix(source, "~") {
+		return fmt.Errorf("bind mount %s refers to home folder of host", source)
+	}
+
+	if !path.IsAbs(source) {
+		if !isInsideFolder(source) {
+			return fmt.Errorf("bind mount %s is outside of compose folder", source)
+		}
+
+		if !files.exists(source) {
+			return fmt.Errorf("bind mount %s doesn't exist in %s", source, files.location)
+		}
+
+		return nil
+	}
+
+	cleanSource := path.Clean(source)
+	for _, allowed := range allowedBindPaths {
+		allowed = path.Clean(allowed)
+		if cleanSource == allowed || strings.HasPrefix(cleanSource, allowed+"/") {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("bind mount %s is not under an allowed host path", source)
+}
+
+// isInsideFolder - function to check if relative path stays inside folder
+func isInsideFolder(relPath string) bool {
+	cleanPath := path.Clean(relPath)
+
+	return cleanPath != ".." && !strings.HasPrefix(cleanPath, "../")
+}
+
+// envFiles - function to get paths of env_file, which can be a string, list of strings or list of maps with path
+func envFiles(envFile interface{}) []string {
+	switch value := envFile.(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		var files []string
+		for _, item := range value {
+			switch file := item.(type) {
+			case string:
+				files = append(files, file)
+			case map[string]interface{}:
+				if required, ok := file["required"].(bool); ok && !required {
+					continue
+				}
+				if filePath, ok := file["path"].(string); ok {
+					files = append(files, filePath)
+				}
+			}
+		}
+		return files
+	}
+
+	return nil
+}
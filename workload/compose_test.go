@@ -0,0 +1,151 @@
+package workload
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+	"github.com/Sashwat-K/lib-hpcr/contract"
+)
+
+const (
+	sampleComposeFolderPath = "../samples/tgz"
+
+	sampleInvalidCompose = `
+services:
+  app:
+    build: .
+    privileged: true
+    env_file:
+      - app.env
+      - missing.env
+    volumes:
+      - ./config:/etc/app
+      - /var/run/docker.sock:/var/run/docker.sock
+      - /mnt/data:/data
+      - data:/var/lib/app
+  web:
+    image: nginx:latest
+volumes:
+  data:
+`
+)
+
+// invalidComposeFolder - function to create compose folder with constructs HPCR can't run
+func invalidComposeFolder(t *testing.T) string {
+	folder := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(folder, "docker-compose.yaml"), []byte(sampleInvalidCompose), 0644)
+	if err != nil {
+		t.Fatalf("failed to write compose file - %v", err)
+	}
+
+	err = os.WriteFile(filepath.Join(folder, "app.env"), []byte("KEY=value"), 0644)
+	if err != nil {
+		t.Fatalf("failed to write env file - %v", err)
+	}
+
+	err = os.Mkdir(filepath.Join(folder, "config"), 0755)
+	if err != nil {
+		t.Fatalf("failed to create config folder - %v", err)
+	}
+
+	return folder
+}
+
+// Testcase to check if HpcrComposeValidate() reports findings for each unsupported construct
+func TestHpcrComposeValidate(t *testing.T) {
+	result, err := HpcrComposeValidate(sampleComposeFolderPath, ComposeOptions{})
+	if err != nil {
+		t.Errorf("failed to validate compose - %v", err)
+	}
+
+	assert.Empty(t, result)
+
+	result, err = HpcrComposeValidate(invalidComposeFolder(t), ComposeOptions{AllowedBindPaths: []string{"/mnt/data"}})
+	if err != nil {
+		t.Errorf("failed to validate compose - %v", err)
+	}
+
+	assert.ElementsMatch(t, result, []Finding{
+		{Severity: SeverityError, Rule: RuleBuild, Service: "app", Message: "build is not supported, use a prebuilt image"},
+		{Severity: SeverityError, Rule: RulePrivileged, Service: "app", Message: "privileged containers are not allowed"},
+		{Severity: SeverityError, Rule: RuleImage, Service: "app", Message: "image is missing"},
+		{Severity: SeverityError, Rule: RuleBindMount, Service: "app", Message: "bind mount /var/run/docker.sock is not under an allowed host path"},
+		{Severity: SeverityError, Rule: RuleEnvFile, Service: "app", Message: "env_file missing.env doesn't exist in compose folder"},
+		{Severity: SeverityWarning, Rule: RuleImage, Service: "web", Message: "image nginx:latest is not fully qualified, registry is missing"},
+		{Severity: SeverityWarning, Rule: RuleMutableTag, Service: "web", Message: "image nginx:latest is referenced by tag instead of digest"},
+	})
+
+	result, err = HpcrComposeValidate(t.TempDir(), ComposeOptions{})
+	if err != nil {
+		t.Errorf("failed to validate compose - %v", err)
+	}
+
+	assert.Equal(t, result[0].Rule, RuleComposeFile)
+}
+
+// Testcase to check if HpcrComposeArchive() generates archive only for valid compose folder
+func TestHpcrComposeArchive(t *testing.T) {
+	result, _, _, findings, err := HpcrComposeArchive(sampleComposeFolderPath, ComposeOptions{RequireDigests: true}, contract.Options{})
+	if err != nil {
+		t.Errorf("failed to generate compose archive - %v", err)
+	}
+
+	assert.NotEmpty(t, result)
+	assert.Empty(t, findings)
+
+	_, _, _, findings, err = HpcrComposeArchive(invalidComposeFolder(t), ComposeOptions{}, contract.Options{})
+	assert.Error(t, err)
+	assert.True(t, HasErrors(findings))
+}
+
+// Testcase to check if HpcrComposeArchive() reports files referred by compose file that .hpcrignore leaves out of archive
+func TestHpcrComposeArchiveIgnored(t *testing.T) {
+	folder := t.TempDir()
+
+	for name, content := range map[string]string{
+		"docker-compose.yaml": "services:\n  app:\n    image: us.icr.io/example/app@sha256:" + strings.Repeat("a", 64) + "\n    env_file: .env\n    volumes:\n      - ./config:/etc/app\n",
+		".env":                "KEY=value",
+		"config/app.yaml":     "key: value",
+		gen.HpcrIgnoreFile:    ".env\nconfig/\n",
+	} {
+		err := os.MkdirAll(filepath.Dir(filepath.Join(folder, name)), 0755)
+		if err != nil {
+			t.Fatalf("failed to create folder - %v", err)
+		}
+
+		err = os.WriteFile(filepath.Join(folder, name), []byte(content), 0644)
+		if err != nil {
+			t.Fatalf("failed to write file - %v", err)
+		}
+	}
+
+	findings, err := HpcrComposeValidate(folder, ComposeOptions{})
+	if err != nil {
+		t.Errorf("failed to validate compose - %v", err)
+	}
+
+	assert.Empty(t, findings)
+
+	_, _, _, findings, err = HpcrComposeArchive(folder, ComposeOptions{}, contract.Options{})
+	assert.Error(t, err)
+	assert.ElementsMatch(t, findings, []Finding{
+		{Severity: SeverityError, Rule: RuleBindMount, Service: "app", Message: "bind mount ./config doesn't exist in archive"},
+		{Severity: SeverityError, Rule: RuleEnvFile, Service: "app", Message: "env_file .env doesn't exist in archive"},
+	})
+
+	_, _, _, findings, err = HpcrComposeArchive(folder, ComposeOptions{}, contract.Options{Tgz: gen.TgzOptions{Exclude: []string{"docker-compose.yaml"}}})
+	assert.Error(t, err)
+	assert.Equal(t, findings[0].Rule, RuleComposeFile)
+}
+
+// Testcase to check if HasErrors() only counts error findings
+func TestHasErrors(t *testing.T) {
+	assert.False(t, HasErrors([]Finding{{Severity: SeverityWarning}}))
+	assert.True(t, HasErrors([]Finding{{Severity: SeverityWarning}, {Severity: SeverityError}}))
+}
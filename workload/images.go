@@ -0,0 +1,237 @@
+package workload
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+	"github.com/Sashwat-K/lib-hpcr/contract"
+)
+
+// rule of image signature findings
+const (
+	RuleImageSignature = "image-signature"
+
+	pgpPublicKeyHeader = "-----BEGIN PGP PUBLIC KEY BLOCK-----"
+	pgpPublicKeyFooter = "-----END PGP PUBLIC KEY BLOCK-----"
+)
+
+var (
+	// reDctImageName tests if image name has registry, namespace and repository as required for dct entries
+	reDctImageName = regexp.MustCompile(`^(([a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9\-]*[a-zA-Z0-9])\.)*([A-Za-z0-9]|[A-Za-z0-9][A-Za-z0-9\-]*[A-Za-z0-9])/[a-z0-9_-]{3,30}/[a-z0-9_-]{2,255}$`)
+)
+
+type (
+	// DctKey - notary server and PEM public key to verify images with Docker Content Trust
+	DctKey struct {
+		Notary    string
+		PublicKey string
+	}
+
+	// ImageSigningKeys - keys to verify images, the key of each map is an image name or a prefix of image names (eg: us.icr.io/namespace)
+	ImageSigningKeys struct {
+		// Dct - notary settings for Docker Content Trust
+		Dct map[string]DctKey
+		// Rhs - ASCII armored GPG public keys for Red Hat simple signing
+		Rhs map[string]string
+	}
+)
+
+// HpcrArchiveImages - function to get sorted images of compose services and Pod containers in base64 archive
+func HpcrArchiveImages(tgzBase64 string) ([]string, error) {
+	if gen.CheckIfEmpty(tgzBase64) {
+		return nil, fmt.Errorf(missingParameterErrStatement)
+	}
+
+	entries, err := gen.ReadTgzBase64(tgzBase64)
+	if err != nil {
+		return nil, err
+	}
+
+	found := map[string]bool{}
+
+	for _, entry := range entries {
+		extension := path.Ext(entry.Name)
+		if extension != ".yaml" && extension != ".yml" {
+			continue
+		}
+
+		documents, err := decodeYamlDocuments(entry.Content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s - %v", entry.Name, err)
+		}
+
+		for _, document := range documents {
+			for _, image := range imageNodes(document) {
+				found[image.Value] = true
+			}
+		}
+	}
+
+	images := make([]string, 0, len(found))
+	for image := range found {
+		images = append(images, image)
+	}
+	sort.Strings(images)
+
+	return images, nil
+}
+
+// HpcrImagesConfig - function to generate images section of workload with matching signing key for each image
+// Returns the section and findings of HpcrCheckImagesConfig, error if any image has no valid entry
+func HpcrImagesConfig(images []string, keys ImageSigningKeys) (contract.Images, []Finding, error) {
+	if len(images) == 0 {
+		return contract.Images{}, nil, fmt.Errorf(missingParameterErrStatement)
+	}
+
+	config := contract.Images{}
+
+	dctPrefixes := make([]string, 0, len(keys.Dct))
+	for prefix := range keys.Dct {
+		dctPrefixes = append(dctPrefixes, prefix)
+	}
+
+	rhsPrefixes := make([]string, 0, len(keys.Rhs))
+	for prefix := range keys.Rhs {
+		rhsPrefixes = append(rhsPrefixes, prefix)
+	}
+
+	for _, image := range images {
+		reference, err := ParseImageReference(image)
+		if err != nil {
+			return contract.Images{}, nil, err
+		}
+
+		name := reference.Name()
+
+		if prefix, ok := matchImagePrefix(name, dctPrefixes); ok {
+			key := keys.Dct[prefix]
+
+			err = validateDctKey(key)
+			if err != nil {
+				return contract.Images{}, nil, fmt.Errorf("dct key of %s - %v", prefix, err)
+			}
+
+			if config.Dct == nil {
+				config.Dct = map[string]contract.DctImage{}
+			}
+			config.Dct[name] = contract.DctImage{Notary: key.Notary, PublicKey: base64.StdEncoding.EncodeToString([]byte(key.PublicKey))}
+		}
+
+		if prefix, ok := matchImagePrefix(name, rhsPrefixes); ok {
+			key := keys.Rhs[prefix]
+
+			err = validateRhsKey(key)
+			if err != nil {
+				return contract.Images{}, nil, fmt.Errorf("rhs key of %s - %v", prefix, err)
+			}
+
+			if config.Rhs == nil {
+				config.Rhs = map[string]contract.RhsImage{}
+			}
+			config.Rhs[name] = contract.RhsImage{PublicKey: base64.StdEncoding.EncodeToString([]byte(key))}
+		}
+	}
+
+	findings := HpcrCheckImagesConfig(config, images)
+	if HasErrors(findings) {
+		return contract.Images{}, findings, fmt.Errorf("images config has %d error(s)", countErrors(findings))
+	}
+
+	return config, findings, nil
+}
+
+// HpcrCheckImagesConfig - function to check that every image has exactly one verification entry in images section and the entry names are valid
+func HpcrCheckImagesConfig(config contract.Images, images []string) []Finding {
+	var findings []Finding
+
+	add := func(message string, args ...interface{}) {
+		findings = append(findings, Finding{Severity: SeverityError, Rule: RuleImageSignature, Message: fmt.Sprintf(message, args...)})
+	}
+
+	rhsNames := map[string]bool{}
+	for key := range config.Rhs {
+		reference, err := ParseImageReference(key)
+		if err != nil || !reference.IsFullyQualified() {
+			add("rhs entry %s is not a fully qualified image name", key)
+			continue
+		}
+		rhsNames[reference.Name()] = true
+	}
+
+	for key := range config.Dct {
+		if !reDctImageName.MatchString(key) {
+			add("dct entry %s is not in registry/namespace/repository format", key)
+		}
+	}
+
+	for _, image := range images {
+		reference, err := ParseImageReference(image)
+		if err != nil {
+			add("%v", err)
+			continue
+		}
+
+		name := reference.Name()
+		_, hasDct := config.Dct[name]
+		hasRhs := rhsNames[name]
+
+		switch {
+		case !hasDct && !hasRhs:
+			add("image %s has no dct or rhs entry", image)
+		case hasDct && hasRhs:
+			add("image %s has both dct and rhs entries", image)
+		}
+	}
+
+	sort.Slice(findings, func(i, j int) bool {
+		return findings[i].Message < findings[j].Message
+	})
+
+	return findings
+}
+
+// matchImagePrefix - function to find prefix (image name or prefix of image names) of image name, the longest match on a path boundary wins
+func matchImagePrefix(name string, prefixes []string) (string, bool) {
+	match := ""
+
+	for _, prefix := range prefixes {
+		trimmed := strings.TrimSuffix(prefix, "/")
+		if (name == trimmed || strings.HasPrefix(name, trimmed+"/")) && len(prefix) > len(match) {
+			match = prefix
+		}
+	}
+
+	return match, match != ""
+}
+
+// validateDctKey - function to check that notary is a https URL and public key is PEM
+func validateDctKey(key DctKey) error {
+	notary, err := url.Parse(key.Notary)
+	if err != nil || notary.Scheme != "https" || notary.Host == "" {
+		return fmt.Errorf("notary %q is not a https URL", key.Notary)
+	}
+
+	block, _ := pem.Decode([]byte(key.PublicKey))
+	if block == nil || !strings.Contains(block.Type, "PUBLIC KEY") {
+		return fmt.Errorf("public key is not in PEM format")
+	}
+
+	return nil
+}
+
+// validateRhsKey - function to check that GPG public key is ASCII armored
+func validateRhsKey(key string) error {
+	key = strings.TrimSpace(key)
+	if !strings.HasPrefix(key, pgpPublicKeyHeader) || !strings.HasSuffix(key, pgpPublicKeyFooter) {
+		return fmt.Errorf("public key is not an ASCII armored GPG public key")
+	}
+
+	return nil
+}
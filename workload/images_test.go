@@ -0,0 +1,112 @@
+package workload
+
+import (
+	"encoding/base64"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Sashwat-K/lib-hpcr/contract"
+)
+
+const (
+	sampleDctPublicKeyPath = "../samples/images/dct-public.pem"
+	sampleRhsPublicKeyPath = "../samples/images/rhs-public.asc"
+	sampleNotaryUrl        = "https://notary.us.icr.io"
+)
+
+// imageSigningKeys - function to read sample keys for images of us.icr.io/example namespace (dct) and quay.io/example/web (rhs)
+func imageSigningKeys(t *testing.T) (ImageSigningKeys, string, string) {
+	dctPublicKey, err := os.ReadFile(sampleDctPublicKeyPath)
+	if err != nil {
+		t.Fatalf("failed to read dct public key - %v", err)
+	}
+
+	rhsPublicKey, err := os.ReadFile(sampleRhsPublicKeyPath)
+	if err != nil {
+		t.Fatalf("failed to read rhs public key - %v", err)
+	}
+
+	keys := ImageSigningKeys{
+		Dct: map[string]DctKey{"us.icr.io/example": {Notary: sampleNotaryUrl, PublicKey: string(dctPublicKey)}},
+		Rhs: map[string]string{"quay.io/example/web": string(rhsPublicKey)},
+	}
+
+	return keys, string(dctPublicKey), string(rhsPublicKey)
+}
+
+// Testcase to check if HpcrArchiveImages() lists images of compose archive once
+func TestHpcrArchiveImages(t *testing.T) {
+	archive, _, _, err := contract.HpcrTgzFromMap(map[string]string{
+		"docker-compose.yaml": sampleMultiRegistryCompose,
+		"pod.yaml":            sampleTaggedPlayPodYaml,
+	}, contract.Options{})
+	if err != nil {
+		t.Errorf("failed to generate archive - %v", err)
+	}
+
+	result, err := HpcrArchiveImages(archive)
+	if err != nil {
+		t.Errorf("failed to get images of archive - %v", err)
+	}
+
+	assert.Equal(t, result, []string{
+		"docker.io/library/hello-world",
+		"icr.io/example/nginx:1.25",
+		"quay.io/example/proxy:1.0",
+		"quay.io/example/web:1.0",
+		"redis:7",
+		"us.icr.io/example/app:1.0",
+	})
+}
+
+// Testcase to check if HpcrImagesConfig() generates dct and rhs entries from keys matching image names or prefixes
+func TestHpcrImagesConfig(t *testing.T) {
+	keys, dctPublicKey, rhsPublicKey := imageSigningKeys(t)
+
+	result, findings, err := HpcrImagesConfig([]string{"us.icr.io/example/app:1.0", "us.icr.io/example/db:2.0", "quay.io/example/web:1.0"}, keys)
+	if err != nil {
+		t.Errorf("failed to generate images config - %v", err)
+	}
+
+	assert.Empty(t, findings)
+	assert.Equal(t, result, contract.Images{
+		Dct: map[string]contract.DctImage{
+			"us.icr.io/example/app": {Notary: sampleNotaryUrl, PublicKey: base64.StdEncoding.EncodeToString([]byte(dctPublicKey))},
+			"us.icr.io/example/db":  {Notary: sampleNotaryUrl, PublicKey: base64.StdEncoding.EncodeToString([]byte(dctPublicKey))},
+		},
+		Rhs: map[string]contract.RhsImage{
+			"quay.io/example/web": {PublicKey: base64.StdEncoding.EncodeToString([]byte(rhsPublicKey))},
+		},
+	})
+
+	_, findings, err = HpcrImagesConfig([]string{"us.icr.io/example/app:1.0", "quay.io/example/proxy:1.0"}, keys)
+	assert.Error(t, err)
+	assert.Equal(t, findings, []Finding{{Severity: SeverityError, Rule: RuleImageSignature, Message: "image quay.io/example/proxy:1.0 has no dct or rhs entry"}})
+
+	keys.Rhs["quay.io/example/web"] = dctPublicKey
+	_, _, err = HpcrImagesConfig([]string{"quay.io/example/web:1.0"}, keys)
+	assert.Error(t, err)
+}
+
+// Testcase to check if HpcrCheckImagesConfig() reports images without entry, images with both entries and invalid entry names
+func TestHpcrCheckImagesConfig(t *testing.T) {
+	config := contract.Images{
+		Dct: map[string]contract.DctImage{
+			"us.icr.io/example/app": {Notary: sampleNotaryUrl},
+			"us.icr.io/app":         {Notary: sampleNotaryUrl},
+		},
+		Rhs: map[string]contract.RhsImage{
+			"us.icr.io/example/app@" + sampleImageDigest: {},
+		},
+	}
+
+	result := HpcrCheckImagesConfig(config, []string{"us.icr.io/example/app:1.0", "quay.io/example/web:1.0"})
+
+	assert.Equal(t, result, []Finding{
+		{Severity: SeverityError, Rule: RuleImageSignature, Message: "dct entry us.icr.io/app is not in registry/namespace/repository format"},
+		{Severity: SeverityError, Rule: RuleImageSignature, Message: "image quay.io/example/web:1.0 has no dct or rhs entry"},
+		{Severity: SeverityError, Rule: RuleImageSignature, Message: "image us.icr.io/example/app:1.0 has both dct and rhs entries"},
+	})
+}
@@ -0,0 +1,406 @@
+package workload
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+	"github.com/Sashwat-K/lib-hpcr/contract"
+)
+
+const (
+	lockReportVersion = "1"
+
+	ociIndexFile           = "index.json"
+	ociRefNameAnnotation   = "org.opencontainers.image.ref.name"
+	containerdNameAnnotion = "io.containerd.image.name"
+
+	defaultRegistry = "docker.io"
+	defaultTag      = "latest"
+)
+
+type (
+	// LockEntry - digest an image reference was pinned to
+	LockEntry struct {
+		// Image - image reference as written in compose or play file
+		Image string `json:"image"`
+		// Digest - resolved digest
+		Digest string `json:"digest"`
+		// Pinned - image reference written back (name@digest)
+		Pinned string `json:"pinned"`
+	}
+
+	// LockReport - tag to digest report of pinning, can be used as lockfile
+	LockReport struct {
+		Version string      `json:"version"`
+		Images  []LockEntry `json:"images"`
+	}
+
+	// ociIndex - index.json of OCI image layout
+	ociIndex struct {
+		Manifests []struct {
+			Digest      string            `json:"digest"`
+			Annotations map[string]string `json:"annotations"`
+		} `json:"manifests"`
+	}
+)
+
+// ReadOciLayoutDigests - function to read image reference to digest map from index.json of local OCI image layout
+// The reference is taken from io.containerd.image.name or from org.opencontainers.image.ref.name if it is a full reference
+func ReadOciLayoutDigests(layoutPath string) (map[string]string, error) {
+	if gen.CheckIfEmpty(layoutPath) {
+		return nil, fmt.Errorf(missingParameterErrStatement)
+	}
+
+	data, err := os.ReadFile(filepath.Join(layoutPath, ociIndexFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OCI layout index - %v", err)
+	}
+
+	var index ociIndex
+	err = json.Unmarshal(data, &index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal OCI layout index - %v", err)
+	}
+
+	digests := map[string]string{}
+
+	for _, manifest := range index.Manifests {
+		image := manifest.Annotations[containerdNameAnnotion]
+		if image == "" {
+			image = manifest.Annotations[ociRefNameAnnotation]
+		}
+
+		reference, err := ParseImageReference(image)
+		if err != nil || !strings.Contains(image, "/") {
+			continue
+		}
+
+		if !reImageDigest.MatchString(manifest.Digest) {
+			return nil, fmt.Errorf("invalid digest of %s in OCI layout index", image)
+		}
+
+		digests[normalizeReference(reference)] = manifest.Digest
+	}
+
+	return digests, nil
+}
+
+// ReadLockfileDigests - function to read image reference to digest map from lock report generated by HpcrPinImages
+func ReadLockfileDigests(lockfile string) (map[string]string, error) {
+	var report LockReport
+
+	err := json.Unmarshal([]byte(lockfile), &report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal lockfile - %v", err)
+	}
+
+	if report.Version != lockReportVersion {
+		return nil, fmt.Errorf("unsupported lockfile version - %s", report.Version)
+	}
+
+	digests := map[string]string{}
+
+	for _, entry := range report.Images {
+		reference, err := ParseImageReference(entry.Image)
+		if err != nil {
+			return nil, fmt.Errorf("invalid image in lockfile - %v", err)
+		}
+
+		if !reImageDigest.MatchString(entry.Digest) {
+			return nil, fmt.Errorf("invalid digest of %s in lockfile", entry.Image)
+		}
+
+		digests[normalizeReference(reference)] = entry.Digest
+	}
+
+	return digests, nil
+}
+
+// HpcrPinImages - function to rewrite images of compose and play files (path to content) to name@digest and return them with lock report
+// Images that are already pinned are kept, images without digest in digests cause an error
+func HpcrPinImages(files map[string]string, digests map[string]string) (map[string]string, string, error) {
+	if len(files) == 0 {
+		return nil, "", fmt.Errorf(missingParameterErrStatement)
+	}
+
+	locked := map[string]LockEntry{}
+	var unresolved []string
+
+	pinned := map[string]string{}
+	for name, content := range files {
+		extension := path.Ext(name)
+		if extension != ".yaml" && extension != ".yml" {
+			pinned[name] = content
+			continue
+		}
+
+		result, err := pinDocuments(content, func(image string) string {
+			entry, ok := resolveImage(image, digests)
+			if !ok {
+				unresolved = append(unresolved, image)
+				return image
+			}
+
+			locked[entry.Image] = entry
+			return entry.Pinned
+		})
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to pin images of %s - %v", name, err)
+		}
+
+		pinned[name] = result
+	}
+
+	if len(unresolved) > 0 {
+		sort.Strings(unresolved)
+		return nil, "", fmt.Errorf("digest not found for image(s) - %s", strings.Join(unresolved, ", "))
+	}
+
+	report := LockReport{Version: lockReportVersion, Images: []LockEntry{}}
+	for _, entry := range locked {
+		report.Images = append(report.Images, entry)
+	}
+	sort.Slice(report.Images, func(i, j int) bool {
+		return report.Images[i].Image < report.Images[j].Image
+	})
+
+	lockReport, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to marshal lock report - %v", err)
+	}
+
+	return pinned, string(lockReport) + "\n", nil
+}
+
+// HpcrPinFolder - function to pin images of compose or play files in folder and generate base64 archive of the pinned folder
+// Returns archive, checksum of content manifest, checksum of archive and lock report
+func HpcrPinFolder(folderPath string, digests map[string]string, opts contract.Options) (string, string, string, string, error) {
+	if gen.CheckIfEmpty(folderPath) {
+		return "", "", "", "", fmt.Errorf(missingParameterErrStatement)
+	}
+
+	tgzBase64, _, _, err := contract.HpcrTgzWithOptions(folderPath, contract.Options{Tgz: gen.TgzOptions{Exclude: opts.Tgz.Exclude, Include: opts.Tgz.Include}})
+	if err != nil {
+		return "", "", "", "", err
+	}
+
+	pinnedFolder, err := os.MkdirTemp("", "hpcr-pin-")
+	if err != nil {
+		return "", "", "", "", fmt.Errorf("failed to create temporary folder - %v", err)
+	}
+	defer os.RemoveAll(pinnedFolder)
+
+	_, err = gen.ExtractTgzBase64(tgzBase64, pinnedFolder)
+	if err != nil {
+		return "", "", "", "", err
+	}
+
+	entries, err := gen.ReadTgzBase64(tgzBase64)
+	if err != nil {
+		return "", "", "", "", err
+	}
+
+	files := map[string]string{}
+	for _, entry := range entries {
+		extension := path.Ext(entry.Name)
+		if entry.Typeflag == tar.TypeReg && (extension == ".yaml" || extension == ".yml") {
+			files[entry.Name] = entry.Content
+		}
+	}
+
+	if len(files) == 0 {
+		return "", "", "", "", fmt.Errorf("folder doesn't contain YAML files")
+	}
+
+	pinnedFiles, lockReport, err := HpcrPinImages(files, digests)
+	if err != nil {
+		return "", "", "", "", err
+	}
+
+	for name, content := range pinnedFiles {
+		filePath := filepath.Join(pinnedFolder, filepath.FromSlash(name))
+
+		info, err := os.Stat(filePath)
+		if err != nil {
+			return "", "", "", "", err
+		}
+
+		err = os.WriteFile(filePath, []byte(content), info.Mode().Perm())
+		if err != nil {
+			return "", "", "", "", fmt.Errorf("failed to write %s - %v", name, err)
+		}
+	}
+
+	pinnedTgz, manifestSha256, outputSha256, err := contract.HpcrTgzWithOptions(pinnedFolder, opts)
+	if err != nil {
+		return "", "", "", "", err
+	}
+
+	return pinnedTgz, manifestSha256, outputSha256, lockReport, nil
+}
+
+// resolveImage - function to find digest of image, already pinned images resolve to themselves
+func resolveImage(image string, digests map[string]string) (LockEntry, bool) {
+	reference, err := ParseImageReference(image)
+	if err != nil {
+		return LockEntry{}, false
+	}
+
+	if reference.Digest != "" {
+		return LockEntry{Image: image, Digest: reference.Digest, Pinned: image}, true
+	}
+
+	digest, ok := digests[normalizeReference(reference)]
+	if !ok {
+		return LockEntry{}, false
+	}
+
+	return LockEntry{Image: image, Digest: digest, Pinned: reference.Name() + "@" + digest}, true
+}
+
+// normalizeReference - function to get reference with default registry, library namespace and tag, used as key of digests
+func normalizeReference(reference ImageReference) string {
+	registry := reference.Registry
+	repository := reference.Repository
+
+	if registry == "" {
+		registry = defaultRegistry
+	}
+
+	if registry == defaultRegistry && !strings.Contains(repository, "/") {
+		repository = "library/" + repository
+	}
+
+	tag := reference.Tag
+	if tag == "" {
+		tag = defaultTag
+	}
+
+	return registry + "/" + repository + ":" + tag
+}
+
+// pinDocuments - function to replace images in all compose and Pod documents of YAML content
+func pinDocuments(content string, pin func(image string) string) (string, error) {
+	documents, err := decodeYamlDocuments(content)
+	if err != nil {
+		return "", err
+	}
+
+	changed := false
+	for _, document := range documents {
+		for _, image := range imageNodes(document) {
+			pinnedImage := pin(image.Value)
+			if pinnedImage != image.Value {
+				image.Value = pinnedImage
+				changed = true
+			}
+		}
+	}
+
+	if !changed {
+		return content, nil
+	}
+
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	for _, document := range documents {
+		err = encoder.Encode(document)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// decodeYamlDocuments - function to decode all documents of YAML content as nodes
+func decodeYamlDocuments(content string) ([]*yaml.Node, error) {
+	decoder := yaml.NewDecoder(strings.NewReader(content))
+
+	var documents []*yaml.Node
+	for {
+		var document yaml.Node
+
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			return documents, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		documents = append(documents, &document)
+	}
+}
+
+// imageNodes - function to get image scalar nodes of compose services or Pod containers
+func imageNodes(document *yaml.Node) []*yaml.Node {
+	if document.Kind != yaml.DocumentNode || len(document.Content) == 0 {
+		return nil
+	}
+
+	root := document.Content[0]
+
+	var images []*yaml.Node
+
+	if services := mappingValue(root, "services"); services != nil && services.Kind == yaml.MappingNode {
+		for i := 1; i < len(services.Content); i += 2 {
+			if image := mappingValue(services.Content[i], "image"); image != nil && image.Kind == yaml.ScalarNode {
+				images = append(images, image)
+			}
+		}
+	}
+
+	if kind := mappingValue(root, "kind"); kind != nil && kind.Value == "Pod" {
+		spec := mappingValue(root, "spec")
+		for _, key := range []string{"containers", "initContainers"} {
+			containers := mappingValue(spec, key)
+			if containers == nil || containers.Kind != yaml.SequenceNode {
+				continue
+			}
+
+			for _, container := range containers.Content {
+				if image := mappingValue(container, "image"); image != nil && image.Kind == yaml.ScalarNode {
+					images = append(images, image)
+				}
+			}
+		}
+	}
+
+	return images
+}
+
+// mappingValue - function to get value node of key in mapping node
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
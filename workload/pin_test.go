@@ -0,0 +1,143 @@
+package workload
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+	"github.com/Sashwat-K/lib-hpcr/contract"
+)
+
+const (
+	sampleOciLayoutPath     = "../samples/pin/oci-layout"
+	samplePinComposeFolder  = "../samples/pin/compose"
+	sampleNginxImageDigest  = "sha256:0d9c7f5f4b4d6a1a1b3f6b2c1c4e0d8c9a8e7f6d5c4b3a29180716253443526a"
+	sampleTaggedPlayPodYaml = `apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+spec:
+  initContainers:
+    - name: init
+      image: docker.io/library/hello-world
+  containers:
+    - name: web
+      image: icr.io/example/nginx:1.25
+`
+)
+
+// Testcase to check if ReadOciLayoutDigests() reads digests of images with full reference
+func TestReadOciLayoutDigests(t *testing.T) {
+	result, err := ReadOciLayoutDigests(sampleOciLayoutPath)
+	if err != nil {
+		t.Errorf("failed to read OCI layout - %v", err)
+	}
+
+	assert.Equal(t, result, map[string]string{
+		"docker.io/library/hello-world:latest": sampleImageDigest,
+		"icr.io/example/nginx:1.25":            sampleNginxImageDigest,
+	})
+
+	_, err = ReadOciLayoutDigests(t.TempDir())
+	assert.Error(t, err)
+
+	layoutPath := t.TempDir()
+	err = os.WriteFile(filepath.Join(layoutPath, "index.json"), []byte(`{"manifests": [{"digest": "sha256:abc", "annotations": {"io.containerd.image.name": "icr.io/example/nginx:1.25"}}]}`), 0644)
+	if err != nil {
+		t.Fatalf("failed to write OCI layout index - %v", err)
+	}
+
+	_, err = ReadOciLayoutDigests(layoutPath)
+	assert.ErrorContains(t, err, "invalid digest of icr.io/example/nginx:1.25")
+}
+
+// Testcase to check if ReadLockfileDigests() reads lock report generated by HpcrPinImages()
+func TestReadLockfileDigests(t *testing.T) {
+	digests, err := ReadOciLayoutDigests(sampleOciLayoutPath)
+	if err != nil {
+		t.Errorf("failed to read OCI layout - %v", err)
+	}
+
+	_, lockReport, err := HpcrPinImages(map[string]string{"pod.yaml": sampleTaggedPlayPodYaml}, digests)
+	if err != nil {
+		t.Errorf("failed to pin images - %v", err)
+	}
+
+	result, err := ReadLockfileDigests(lockReport)
+	if err != nil {
+		t.Errorf("failed to read lockfile - %v", err)
+	}
+
+	assert.Equal(t, result, digests)
+
+	_, err = ReadLockfileDigests(`{"version": "1", "images": [{"image": "icr.io/example/nginx:1.25", "digest": "1.25"}]}`)
+	assert.Error(t, err)
+}
+
+// Testcase to check if HpcrPinImages() rewrites images of Pod to digest and reports them
+func TestHpcrPinImages(t *testing.T) {
+	digests, err := ReadOciLayoutDigests(sampleOciLayoutPath)
+	if err != nil {
+		t.Errorf("failed to read OCI layout - %v", err)
+	}
+
+	files := map[string]string{
+		"pod.yaml":   sampleTaggedPlayPodYaml,
+		"README.txt": "image: icr.io/example/nginx:1.25",
+	}
+
+	result, lockReport, err := HpcrPinImages(files, digests)
+	if err != nil {
+		t.Errorf("failed to pin images - %v", err)
+	}
+
+	assert.Contains(t, result["pod.yaml"], "image: docker.io/library/hello-world@"+sampleImageDigest)
+	assert.Contains(t, result["pod.yaml"], "image: icr.io/example/nginx@"+sampleNginxImageDigest)
+	assert.Equal(t, result["README.txt"], files["README.txt"])
+
+	_, err = HpcrPlayValidate([]string{result["pod.yaml"]})
+	assert.NoError(t, err)
+
+	assert.Contains(t, lockReport, `"image": "icr.io/example/nginx:1.25"`)
+	assert.Contains(t, lockReport, `"pinned": "icr.io/example/nginx@`+sampleNginxImageDigest+`"`)
+
+	_, _, err = HpcrPinImages(map[string]string{"pod.yaml": sampleTaggedPlayPodYaml}, map[string]string{})
+	assert.ErrorContains(t, err, "docker.io/library/hello-world, icr.io/example/nginx:1.25")
+}
+
+// Testcase to check if HpcrPinFolder() generates archive of compose folder with pinned images
+func TestHpcrPinFolder(t *testing.T) {
+	digests, err := ReadOciLayoutDigests(sampleOciLayoutPath)
+	if err != nil {
+		t.Errorf("failed to read OCI layout - %v", err)
+	}
+
+	result, _, outputSha256, lockReport, err := HpcrPinFolder(samplePinComposeFolder, digests, contract.Options{})
+	if err != nil {
+		t.Errorf("failed to pin compose folder - %v", err)
+	}
+
+	assert.Equal(t, gen.GenerateSha256(result), outputSha256)
+	assert.NotEmpty(t, lockReport)
+
+	entries, err := gen.ReadTgzBase64(result)
+	if err != nil {
+		t.Errorf("failed to read archive - %v", err)
+	}
+
+	assert.Len(t, entries, 1)
+	assert.Equal(t, entries[0].Name, "docker-compose.yaml")
+	assert.Contains(t, entries[0].Content, "image: hello-world@"+sampleImageDigest)
+	assert.Contains(t, entries[0].Content, "image: icr.io/example/nginx@"+sampleNginxImageDigest)
+	assert.Contains(t, entries[0].Content, "# web server")
+
+	findings, err := HpcrComposeValidate(samplePinComposeFolder, ComposeOptions{RequireDigests: true})
+	if err != nil {
+		t.Errorf("failed to validate compose - %v", err)
+	}
+
+	assert.True(t, HasErrors(findings))
+}
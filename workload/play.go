@@ -0,0 +1,184 @@
+package workload
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+	"github.com/Sashwat-K/lib-hpcr/contract"
+)
+
+const (
+	missingParameterErrStatement = "required parameter is missing"
+
+	playApiVersion = "v1"
+)
+
+var (
+	// supportedPlayKinds - Kubernetes kinds supported by podman play in HPCR
+	supportedPlayKinds = map[string]bool{
+		"Pod":       true,
+		"ConfigMap": true,
+		"Secret":    true,
+	}
+)
+
+// HpcrPlayValidate - function to validate Kubernetes YAML documents (each may hold several documents) for podman play and return them as resources
+func HpcrPlayValidate(documents []string) ([]map[string]interface{}, error) {
+	if len(documents) == 0 {
+		return nil, fmt.Errorf(missingParameterErrStatement)
+	}
+
+	var resources []map[string]interface{}
+
+	for _, document := range documents {
+		decoder := yaml.NewDecoder(bytes.NewReader([]byte(document)))
+
+		for {
+			var resource map[string]interface{}
+
+			err := decoder.Decode(&resource)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to unmarshal YAML - %v", err)
+			}
+
+			if resource == nil {
+				continue
+			}
+
+			err = validatePlayResource(resource)
+			if err != nil {
+				return nil, fmt.Errorf("resource %d - %v", len(resources)+1, err)
+			}
+
+			resources = append(resources, resource)
+		}
+	}
+
+	if len(resources) == 0 {
+		return nil, fmt.Errorf("no Kubernetes resources found")
+	}
+
+	return resources, nil
+}
+
+// HpcrPlayResources - function to generate inline play section with resources from Kubernetes YAML documents
+func HpcrPlayResources(documents []string) (contract.Play, error) {
+	resources, err := HpcrPlayValidate(documents)
+	if err != nil {
+		return contract.Play{}, err
+	}
+
+	return contract.Play{Resources: resources}, nil
+}
+
+// HpcrPlayArchive - function to generate base64 play archive of folder after validating the YAML files that go into it
+func HpcrPlayArchive(folderPath string, opts contract.Options) (string, string, string, error) {
+	tgzBase64, inputSha256, outputSha256, err := contract.HpcrTgzWithOptions(folderPath, opts)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	err = validatePlayArchive(tgzBase64)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return tgzBase64, inputSha256, outputSha256, nil
+}
+
+// HpcrPlayArchiveFromDocuments - function to validate Kubernetes YAML documents and generate base64 play archive with one file per document
+func HpcrPlayArchiveFromDocuments(documents []string, opts contract.Options) (string, string, string, error) {
+	_, err := HpcrPlayValidate(documents)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	files := map[string]string{}
+	for index, document := range documents {
+		files[fmt.Sprintf("resource-%02d.yaml", index+1)] = document
+	}
+
+	return contract.HpcrTgzFromMap(files, opts)
+}
+
+// validatePlayArchive - function to validate YAML files in play archive
+func validatePlayArchive(tgzBase64 string) error {
+	entries, err := gen.ReadTgzBase64(tgzBase64)
+	if err != nil {
+		return err
+	}
+
+	var documents []string
+	for _, entry := range entries {
+		extension := path.Ext(entry.Name)
+		if extension == ".yaml" || extension == ".yml" {
+			documents = append(documents, entry.Content)
+		}
+	}
+
+	if len(documents) == 0 {
+		return fmt.Errorf("play archive doesn't contain YAML files")
+	}
+
+	_, err = HpcrPlayValidate(documents)
+
+	return err
+}
+
+// validatePlayResource - function to check kind of Kubernetes resource and that images of Pod are fully qualified
+func validatePlayResource(resource map[string]interface{}) error {
+	apiVersion, _ := resource["apiVersion"].(string)
+	kind, _ := resource["kind"].(string)
+
+	if apiVersion != playApiVersion {
+		return fmt.Errorf("%s has unsupported apiVersion %q, expected %s", kind, apiVersion, playApiVersion)
+	}
+
+	if !supportedPlayKinds[kind] {
+		return fmt.Errorf("kind %q is not supported, expected one of Pod, ConfigMap or Secret", kind)
+	}
+
+	metadata, _ := resource["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
+	if name == "" {
+		return fmt.Errorf("%s doesn't have metadata.name", kind)
+	}
+
+	if kind != "Pod" {
+		return nil
+	}
+
+	spec, _ := resource["spec"].(map[string]interface{})
+	containers, _ := spec["containers"].([]interface{})
+	if len(containers) == 0 {
+		return fmt.Errorf("Pod %s doesn't have containers", name)
+	}
+
+	initContainers, _ := spec["initContainers"].([]interface{})
+
+	for _, item := range append(containers, initContainers...) {
+		container, _ := item.(map[string]interface{})
+		image, _ := container["image"].(string)
+
+		if strings.TrimSpace(image) == "" {
+			return fmt.Errorf("container %v of Pod %s doesn't have image", container["name"], name)
+		}
+
+		err := checkFullyQualifiedImage(image)
+		if err != nil {
+			return fmt.Errorf("Pod %s - %v", name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package workload
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	gen "github.com/Sashwat-K/lib-hpcr/common/general"
+	"github.com/Sashwat-K/lib-hpcr/contract"
+)
+
+const (
+	samplePlayFolderPath    = "../samples/play"
+	samplePlayPodPath       = "../samples/play/pod.yaml"
+	samplePlayConfigMapPath = "../samples/play/configmap.yaml"
+
+	sampleShortNamePod = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+spec:
+  containers:
+    - name: web
+      image: nginx:latest
+`
+	sampleDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+`
+)
+
+// playDocuments - function to read sample Kubernetes YAML documents
+func playDocuments(t *testing.T) []string {
+	pod, err := gen.ReadDataFromFile(samplePlayPodPath)
+	if err != nil {
+		t.Fatalf("failed to read pod - %v", err)
+	}
+
+	configMap, err := gen.ReadDataFromFile(samplePlayConfigMapPath)
+	if err != nil {
+		t.Fatalf("failed to read config map - %v", err)
+	}
+
+	return []string{pod, configMap}
+}
+
+// Testcase to check if HpcrPlayValidate() accepts supported resources and rejects others
+func TestHpcrPlayValidate(t *testing.T) {
+	documents := playDocuments(t)
+
+	result, err := HpcrPlayValidate([]string{documents[0] + "\n---\n" + documents[1]})
+	if err != nil {
+		t.Errorf("failed to validate resources - %v", err)
+	}
+
+	assert.Len(t, result, 2)
+
+	_, err = HpcrPlayValidate([]string{sampleShortNamePod})
+	assert.ErrorContains(t, err, "not fully qualified")
+
+	_, err = HpcrPlayValidate([]string{sampleDeployment})
+	assert.ErrorContains(t, err, "unsupported apiVersion")
+
+	_, err = HpcrPlayValidate(nil)
+	assert.Error(t, err)
+}
+
+// Testcase to check if HpcrPlayResources() generates play section that passes contract schema
+func TestHpcrPlayResources(t *testing.T) {
+	result, err := HpcrPlayResources(playDocuments(t))
+	if err != nil {
+		t.Errorf("failed to generate play resources - %v", err)
+	}
+
+	assert.Len(t, result.Resources, 2)
+
+	_, err = contract.NewContractBuilder().WithPlay(result).WithLogDNA("syslog-a.eu-de.logging.cloud.ibm.com", "ab00e3c09p1d4ff7fff9f04c12183413").Build()
+	assert.NoError(t, err)
+}
+
+// Testcase to check if HpcrPlayArchive() generates play archive from folder
+func TestHpcrPlayArchive(t *testing.T) {
+	result, _, _, err := HpcrPlayArchive(samplePlayFolderPath, contract.Options{})
+	if err != nil {
+		t.Errorf("failed to generate play archive - %v", err)
+	}
+
+	entries, err := gen.ReadTgzBase64(result)
+	if err != nil {
+		t.Errorf("failed to read play archive - %v", err)
+	}
+
+	assert.Len(t, entries, 2)
+}
+
+// Testcase to check if HpcrPlayArchiveFromDocuments() generates play archive from documents
+func TestHpcrPlayArchiveFromDocuments(t *testing.T) {
+	result, _, _, err := HpcrPlayArchiveFromDocuments(playDocuments(t), contract.Options{})
+	if err != nil {
+		t.Errorf("failed to generate play archive - %v", err)
+	}
+
+	entries, err := gen.ReadTgzBase64(result)
+	if err != nil {
+		t.Errorf("failed to read play archive - %v", err)
+	}
+
+	assert.Equal(t, entries[0].Name, "resource-01.yaml")
+
+	_, _, _, err = HpcrPlayArchiveFromDocuments([]string{sampleShortNamePod}, contract.Options{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,118 @@
+package workload
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type (
+	// ImageReference - container image reference split into its parts
+	ImageReference struct {
+		// Registry - registry host (with port), empty if reference is not fully qualified
+		Registry string
+		// Repository - repository path without registry
+		Repository string
+		// Tag - tag of image, empty if not given
+		Tag string
+		// Digest - digest of image (eg: sha256:...), empty if not given
+		Digest string
+	}
+)
+
+var (
+	// reImageDigest tests if digest is a valid sha256 digest
+	reImageDigest = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+	// reImageTag tests if tag is a valid tag
+	reImageTag = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+
+	// reImageRepository tests if repository path has only valid characters
+	reImageRepository = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*$`)
+)
+
+// ParseImageReference - function to split image reference into registry, repository, tag and digest
+func ParseImageReference(image string) (ImageReference, error) {
+	var reference ImageReference
+
+	remainder := strings.TrimSpace(image)
+	if remainder == "" {
+		return reference, fmt.Errorf("image reference is empty")
+	}
+
+	if index := strings.Index(remainder, "@"); index >= 0 {
+		reference.Digest = remainder[index+1:]
+		remainder = remainder[:index]
+
+		if !reImageDigest.MatchString(reference.Digest) {
+			return reference, fmt.Errorf("image %s has invalid digest", image)
+		}
+	}
+
+	if index := strings.LastIndex(remainder, ":"); index >= 0 && !strings.Contains(remainder[index+1:], "/") {
+		reference.Tag = remainder[index+1:]
+		remainder = remainder[:index]
+
+		if !reImageTag.MatchString(reference.Tag) {
+			return reference, fmt.Errorf("image %s has invalid tag", image)
+		}
+	}
+
+	if index := strings.Index(remainder, "/"); index >= 0 {
+		host := remainder[:index]
+		if strings.ContainsAny(host, ".:") || host == "localhost" {
+			reference.Registry = host
+			remainder = remainder[index+1:]
+		}
+	}
+
+	reference.Repository = remainder
+	if !reImageRepository.MatchString(reference.Repository) {
+		return reference, fmt.Errorf("image %s has invalid repository", image)
+	}
+
+	return reference, nil
+}
+
+// IsFullyQualified - function to check if reference has registry, as HPCR doesn't resolve short image names
+func (r ImageReference) IsFullyQualified() bool {
+	return r.Registry != ""
+}
+
+// Name - function to get registry and repository of reference
+func (r ImageReference) Name() string {
+	if r.Registry == "" {
+		return r.Repository
+	}
+
+	return r.Registry + "/" + r.Repository
+}
+
+// String - function to get reference as string
+func (r ImageReference) String() string {
+	reference := r.Name()
+
+	if r.Tag != "" {
+		reference += ":" + r.Tag
+	}
+
+	if r.Digest != "" {
+		reference += "@" + r.Digest
+	}
+
+	return reference
+}
+
+// checkFullyQualifiedImage - function to check that image reference is valid and has registry
+func checkFullyQualifiedImage(image string) error {
+	reference, err := ParseImageReference(image)
+	if err != nil {
+		return err
+	}
+
+	if !reference.IsFullyQualified() {
+		return fmt.Errorf("image %s is not fully qualified, registry is missing", image)
+	}
+
+	return nil
+}
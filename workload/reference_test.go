@@ -0,0 +1,43 @@
+package workload
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	sampleImageDigest = "sha256:4bd78111b6914a99dbc560e6a20eab57ff6655aea4a80c50b0c5491968cbc2e6"
+)
+
+// Testcase to check if ParseImageReference() splits image reference into its parts
+func TestParseImageReference(t *testing.T) {
+	result, err := ParseImageReference("docker.io/library/hello-world:latest@" + sampleImageDigest)
+	if err != nil {
+		t.Errorf("failed to parse image reference - %v", err)
+	}
+
+	assert.Equal(t, result, ImageReference{Registry: "docker.io", Repository: "library/hello-world", Tag: "latest", Digest: sampleImageDigest})
+	assert.True(t, result.IsFullyQualified())
+	assert.Equal(t, result.String(), "docker.io/library/hello-world:latest@"+sampleImageDigest)
+
+	result, err = ParseImageReference("localhost:5000/app")
+	if err != nil {
+		t.Errorf("failed to parse image reference - %v", err)
+	}
+
+	assert.Equal(t, result.Registry, "localhost:5000")
+	assert.Empty(t, result.Tag)
+
+	result, err = ParseImageReference("nginx:1.25")
+	if err != nil {
+		t.Errorf("failed to parse image reference - %v", err)
+	}
+
+	assert.False(t, result.IsFullyQualified())
+
+	for _, invalid := range []string{"", "Nginx", "nginx@sha256:abc", "nginx:bad tag"} {
+		_, err = ParseImageReference(invalid)
+		assert.Error(t, err, invalid)
+	}
+}